@@ -2,7 +2,11 @@ module groupie-tracker
 
 go 1.25.0
 
-require fyne.io/fyne/v2 v2.7.2
+require (
+	fyne.io/fyne/v2 v2.7.2
+	golang.org/x/image v0.24.0
+	golang.org/x/text v0.22.0
+)
 
 require (
 	fyne.io/systray v1.12.0 
@@ -32,9 +36,7 @@ require (
 	github.com/srwiley/rasterx v0.0.0-20220730225603-2ab79fcdd4ef 
 	github.com/stretchr/testify v1.11.1 
 	github.com/yuin/goldmark v1.7.8 
-	golang.org/x/image v0.24.0 
 	golang.org/x/net v0.35.0 
 	golang.org/x/sys v0.30.0 
-	golang.org/x/text v0.22.0 
 	gopkg.in/yaml.v3 v3.0.1 
 )
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"groupie-tracker/models"
 	"groupie-tracker/ui"
@@ -14,6 +15,11 @@ import (
 )
 
 func main() {
+	// export de la carte en ligne de commande (sans fenêtre)
+	exportMap := flag.String("export-map", "", "exporte la carte des concerts dans ce fichier PNG puis quitte")
+	exportSize := flag.String("export-size", "1920x1080", "résolution de l'export (LARGEURxHAUTEUR)")
+	flag.Parse()
+
 	log.Println("[START] Loading Groupie Tracker...")
 
 	// Initialize translations cache (pre-load all languages)
@@ -26,6 +32,13 @@ func main() {
 	}
 	log.Println("[OK] Geocode cache initialized")
 
+	if *exportMap != "" {
+		if err := exportMapHeadless(*exportMap, *exportSize); err != nil {
+			log.Fatalf("[EXPORT] %v", err)
+		}
+		return
+	}
+
 	// Créer l'application Fyne avec un ID stable pour les préférences
 	myApp := app.NewWithID("groupie-tracker")
 	log.Println("[OK] Fyne app created")
@@ -41,7 +54,17 @@ func main() {
 	win.Window.ShowAndRun()
 }
 
+// liste gardée entre les pages : recherche, filtres et défilement restent en place
+var artistList *ui.ArtistList
+
 func showArtistList(win *ui.Window) {
+	// liste déjà chargée : on la remet telle quelle
+	if artistList != nil {
+		win.OnRefresh = artistList.Retranslate
+		win.SetContent(artistList.Content())
+		return
+	}
+
 	// Afficher le chargement
 	win.ShowLoading(ui.T().Loading)
 
@@ -68,19 +91,19 @@ func showArtistList(win *ui.Window) {
 		}
 
 		// Créer et afficher la liste
-		list := ui.NewArtistListWithWindow(win, artists, func(artist models.Artist) {
+		list := ui.NewArtistListView(win, artists, func(artist models.Artist) {
 			showArtistDetail(win, artist)
 		}, func() {
 			showMap(win, artists)
+		}, func() {
+			showFavorites(win, artists)
 		})
 
-		// Connecter le callback de refresh pour le bouton langue
-		win.OnRefresh = func() {
-			showArtistList(win)
-		}
-
 		fyne.Do(func() {
-			win.SetContent(list)
+			artistList = list
+			// le bouton langue retraduit la liste sans la reconstruire
+			win.OnRefresh = list.Retranslate
+			win.SetContent(list.Content())
 		})
 	}()
 }
@@ -99,5 +122,36 @@ func showMap(win *ui.Window, artists []models.Artist) {
 
 	ui.NewMapPageWithWindow(win, artists, func() {
 		showArtistList(win)
+	}, func(artist models.Artist) {
+		showArtistDetail(win, artist)
+	})
+}
+
+func showFavorites(win *ui.Window, artists []models.Artist) {
+	ui.NewFavoritesPage(win, artists, func() {
+		showArtistList(win)
+	}, func(artist models.Artist) {
+		showArtistDetail(win, artist)
 	})
 }
+
+func exportMapHeadless(path, size string) error {
+	var width, height int
+	if _, err := fmt.Sscanf(size, "%dx%d", &width, &height); err != nil {
+		return fmt.Errorf("résolution invalide %q (attendu LARGEURxHAUTEUR)", size)
+	}
+
+	artists, err := models.FetchArtists()
+	if err != nil {
+		return err
+	}
+
+	// on sauvegarde le géocodage même si le processus quitte tout de suite
+	defer models.SaveGeocodeCache()
+
+	if err := ui.ExportMapImage(artists, path, width, height); err != nil {
+		return err
+	}
+	log.Printf("[OK] Map exported to %s (%dx%d)\n", path, width, height)
+	return nil
+}
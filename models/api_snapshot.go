@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// dernière réponse valide de l'api gardée sur disque, pour afficher la carte
+// quand l'api ne répond pas
+
+// chemin du fichier de secours d'un endpoint (~/.groupie-tracker-api-relation.json)
+func snapshotPath(name string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = "."
+	}
+	return filepath.Join(homeDir, ".groupie-tracker-api-"+name+".json")
+}
+
+// écrit la réponse en arrière-plan (une erreur est seulement loguée)
+func saveSnapshot(name string, v interface{}) {
+	go func() {
+		data, err := json.Marshal(v)
+		if err == nil {
+			err = os.WriteFile(snapshotPath(name), data, 0644)
+		}
+		if err != nil {
+			log.Printf("[WARN] Failed to save %s snapshot: %v\n", name, err)
+		}
+	}()
+}
+
+func loadSnapshot(name string, v interface{}) (time.Time, error) {
+	path := snapshotPath(name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("aucune copie locale de %s", name)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return time.Time{}, fmt.Errorf("copie locale de %s illisible: %v", name, err)
+	}
+	return info.ModTime(), nil
+}
+
+// LoadRelationsSnapshot renvoie les dernières relations reçues de l'api et leur date
+func LoadRelationsSnapshot() (*RelationData, time.Time, error) {
+	var relations RelationData
+	saved, err := loadSnapshot("relation", &relations)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return &relations, saved, nil
+}
+
+// LoadLocationsSnapshot renvoie les derniers lieux reçus de l'api et leur date
+func LoadLocationsSnapshot() (*LocationData, time.Time, error) {
+	var locations LocationData
+	saved, err := loadSnapshot("locations", &locations)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return &locations, saved, nil
+}
@@ -24,8 +24,9 @@ type Artist struct {
 	Relations    string   `json:"relations"`
 
 	// ajoutés après fetch
-	LocationsList []string `json:"-"` // lieux de concert
-	DatesList     []string `json:"-"` // dates de concert
+	LocationsList []string    `json:"-"` // lieux de concert
+	DatesList     []string    `json:"-"` // dates de concert
+	ConcertTimes  []time.Time `json:"-"` // mêmes dates, lues une fois (illisibles omises)
 }
 
 // relations lieux/dates
@@ -112,12 +113,17 @@ func FetchRelations() (*RelationData, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erreur HTTP: %d", resp.StatusCode)
+	}
+
 	var relations RelationData
 	if err := json.NewDecoder(resp.Body).Decode(&relations); err != nil {
 		return nil, err
 	}
 
 	cachedRelations = &relations
+	saveSnapshot("relation", &relations)
 	return &relations, nil
 }
 
@@ -143,6 +149,7 @@ func FetchLocations() (*LocationData, error) {
 	}
 
 	cachedLocations = &locations
+	saveSnapshot("locations", &locations)
 	return &locations, nil
 }
 
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// format des dates de l'api : "DD-MM-YYYY" (parfois préfixé par "*")
+const concertDateLayout = "02-01-2006"
+
+// TourStop est une étape de tournée : un concert daté dans un lieu géocodé
+type TourStop struct {
+	Location  string
+	Latitude  float64
+	Longitude float64
+	Date      time.Time
+}
+
+// ParseConcertDate parse une date de concert renvoyée par l'api
+func ParseConcertDate(s string) (time.Time, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "*")
+	return time.Parse(concertDateLayout, s)
+}
+
+// SortTour trie les étapes d'une tournée par ordre chronologique
+func SortTour(stops []TourStop) {
+	sort.SliceStable(stops, func(i, j int) bool {
+		return stops[i].Date.Before(stops[j].Date)
+	})
+}
+
+// SortedConcertDates renvoie une copie des dates triée chronologiquement
+// (les dates illisibles sont gardées à la fin)
+func SortedConcertDates(dates []string) []string {
+	out := append([]string(nil), dates...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, errA := ParseConcertDate(out[i])
+		b, errB := ParseConcertDate(out[j])
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		return a.Before(b)
+	})
+	return out
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"strings"
+	"sync"
+)
+
+// continents, dans l'ordre d'affichage
+var Continents = []string{"europe", "north_america", "south_america", "asia", "africa", "oceania"}
+
+// pays par continent (codes ISO 3166-1 alpha-2)
+var continentCountries = map[string]string{
+	"europe": "AL AT BA BE BG BY CH CY CZ DE DK EE ES FI FR GB GR HR HU IE IS IT LT LU LV MD ME MK " +
+		"NL NO PL PT RO RS RU SE SI SK UA",
+	"north_america": "BZ CA CR CU DO GL GT HN HT JM MX NI PA PR SV US",
+	"south_america": "AR BO BR CL CO EC GF GY PE PY SR UY VE",
+	"asia": "AE AF AM AZ BD BT CN GE ID IL IN IQ IR JO JP KG KH KP KR KW KZ LA LB LK MM MN MY NP OM " +
+		"PH PK QA SA SG SY TH TJ TM TR TW UZ VN YE",
+	"africa": "AO BF BI BJ BW CD CF CG CI CM DJ DZ EG EH ER ET GA GH GN GQ GW KE LR LS LY MA MG ML " +
+		"MR MW MZ NA NE NG RW SD SL SN SO SS TD TG TN TZ UG ZA ZM ZW",
+	"oceania": "AU NC NZ PF PG",
+}
+
+var (
+	continentByCode map[string]string
+	continentInit   sync.Once
+)
+
+// Continent renvoie le continent d'un code pays ("FR" -> "europe"), "" si inconnu
+func Continent(code string) string {
+	continentInit.Do(func() {
+		continentByCode = make(map[string]string)
+		for continent, codes := range continentCountries {
+			for _, c := range strings.Fields(codes) {
+				continentByCode[c] = continent
+			}
+		}
+	})
+	return continentByCode[code]
+}
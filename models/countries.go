@@ -0,0 +1,56 @@
+package models
+
+import "strings"
+
+// noms de pays de l'API (partie après le dernier "-" des lieux) qui ne
+// correspondent pas directement au nom des contours embarqués
+var countryAliases = map[string]string{
+	"usa":            "US",
+	"uk":             "GB",
+	"england":        "GB",
+	"scotland":       "GB",
+	"wales":          "GB",
+	"korea":          "KR",
+	"czech_republic": "CZ",
+	"uae":            "AE",
+	"brasil":         "BR",
+	"holland":        "NL",
+	"ivory_coast":    "CI",
+	"macedonia":      "MK",
+	"congo":          "CG",
+}
+
+// CountryCode renvoie le code ISO 3166-1 alpha-2 du pays d'un lieu
+// ("new_york-usa" -> "US"), "" si le pays n'est pas reconnu
+func CountryCode(location string) string {
+	name := location
+	if i := strings.LastIndex(location, "-"); i >= 0 {
+		name = location[i+1:]
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+	if code, ok := countryAliases[name]; ok {
+		return code
+	}
+
+	// sinon on compare au nom des contours ("new_zealand" ~ "New Zealand")
+	for _, c := range WorldCountries() {
+		if strings.ReplaceAll(strings.ToLower(c.Name), " ", "_") == name || strings.ToLower(c.Code) == name {
+			return c.Code
+		}
+	}
+	return ""
+}
+
+// CountryByCode renvoie le contour d'un pays, nil si absent
+func CountryByCode(code string) *CountryShape {
+	countries := WorldCountries()
+	for i := range countries {
+		if countries[i].Code == code {
+			return &countries[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ConcertPlace est un lieu géocodé avec les concerts d'un artiste
+type ConcertPlace struct {
+	Location  string
+	Latitude  float64
+	Longitude float64
+	Artist    string
+	Dates     []string
+}
+
+// dates triées, sans le "*" de l'api (les dates illisibles sont gardées à la fin)
+func (p ConcertPlace) sortedDates() []string {
+	dates := SortedConcertDates(p.Dates)
+	for i, d := range dates {
+		dates[i] = strings.TrimPrefix(strings.TrimSpace(d), "*")
+	}
+	return dates
+}
+
+// "new_york-usa" -> "New York, Usa"
+func placeName(location string) string {
+	words := strings.Fields(strings.NewReplacer("_", " ", "-", ", ").Replace(location))
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w) // "évry" : première lettre sur 2 octets
+		words[i] = string(unicode.ToUpper(r)) + strings.ToLower(w[size:])
+	}
+	return strings.Join(words, " ")
+}
+
+// --- GeoJSON ---
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONPoint           `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"` // [lon, lat]
+}
+
+// WriteGeoJSON écrit une FeatureCollection : un point par couple lieu/artiste
+func WriteGeoJSON(w io.Writer, places []ConcertPlace) error {
+	fc := geoJSONCollection{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(places))}
+	for _, p := range places {
+		dates := p.sortedDates()
+		props := map[string]interface{}{
+			"location": p.Location,
+			"name":     placeName(p.Location),
+			"artist":   p.Artist,
+			"dates":    dates,
+			"concerts": len(dates),
+		}
+		if len(dates) > 0 {
+			props["first_date"] = dates[0]
+			props["last_date"] = dates[len(dates)-1]
+		}
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONPoint{Type: "Point", Coordinates: [2]float64{p.Longitude, p.Latitude}},
+			Properties: props,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fc)
+}
+
+// --- KML ---
+
+type kmlDocument struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document struct {
+		Name       string         `xml:"name"`
+		Placemarks []kmlPlacemark `xml:"Placemark"`
+	} `xml:"Document"`
+}
+
+type kmlPlacemark struct {
+	Name         string    `xml:"name"`
+	Description  string    `xml:"description"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Point        struct {
+		Coordinates string `xml:"coordinates"`
+	} `xml:"Point"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// WriteKML écrit un document KML : un placemark par couple lieu/artiste
+func WriteKML(w io.Writer, title string, places []ConcertPlace) error {
+	doc := kmlDocument{Xmlns: "http://www.opengis.net/kml/2.2"}
+	doc.Document.Name = title
+	for _, p := range places {
+		dates := p.sortedDates()
+		pm := kmlPlacemark{
+			Name:        fmt.Sprintf("%s – %s", p.Artist, placeName(p.Location)),
+			Description: strings.Join(dates, ", "),
+			ExtendedData: []kmlData{
+				{Name: "artist", Value: p.Artist},
+				{Name: "location", Value: p.Location},
+				{Name: "dates", Value: strings.Join(dates, ",")},
+			},
+		}
+		// KML : lon,lat
+		pm.Point.Coordinates = fmt.Sprintf("%f,%f", p.Longitude, p.Latitude)
+		doc.Document.Placemarks = append(doc.Document.Placemarks, pm)
+	}
+	return writeXML(w, doc)
+}
+
+// --- GPX ---
+
+type gpxDocument struct {
+	XMLName  xml.Name `xml:"gpx"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Version  string   `xml:"version,attr"`
+	Creator  string   `xml:"creator,attr"`
+	Metadata struct {
+		Name string `xml:"name"`
+	} `xml:"metadata"`
+	Tracks []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name    string     `xml:"name"`
+	Segment []gpxPoint `xml:"trkseg>trkpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time"`
+	Name string  `xml:"name"`
+}
+
+// WriteGPX écrit la tournée chronologique de chaque artiste comme une trace GPX
+func WriteGPX(w io.Writer, title string, tours map[string][]TourStop) error {
+	doc := gpxDocument{
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Version: "1.1",
+		Creator: "groupie-tracker",
+	}
+	doc.Metadata.Name = title
+
+	artists := make([]string, 0, len(tours))
+	for artist := range tours {
+		artists = append(artists, artist)
+	}
+	sort.Strings(artists)
+
+	for _, artist := range artists {
+		stops := append([]TourStop(nil), tours[artist]...)
+		SortTour(stops)
+		trk := gpxTrack{Name: artist}
+		for _, s := range stops {
+			trk.Segment = append(trk.Segment, gpxPoint{
+				Lat:  s.Latitude,
+				Lon:  s.Longitude,
+				Time: s.Date.UTC().Format(time.RFC3339),
+				Name: placeName(s.Location),
+			})
+		}
+		doc.Tracks = append(doc.Tracks, trk)
+	}
+	return writeXML(w, doc)
+}
+
+// en-tête xml + document indenté
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
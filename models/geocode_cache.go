@@ -74,6 +74,18 @@ func CacheCoords(location string, coords *LocationCoords) {
 	saveCacheMu.Unlock()
 }
 
+// SaveGeocodeCache écrit le cache tout de suite (sans attendre le debounce),
+// utile avant de quitter un processus sans fenêtre
+func SaveGeocodeCache() {
+	saveCacheMu.Lock()
+	if cacheSaveTimer != nil {
+		cacheSaveTimer.Stop()
+		cacheSaveTimer = nil
+	}
+	saveCacheMu.Unlock()
+	saveCacheNow()
+}
+
 // saveCacheNow synchronously saves the cache to disk
 func saveCacheNow() {
 	geocodeCache.mu.Lock()
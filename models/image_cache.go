@@ -0,0 +1,121 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"golang.org/x/image/draw"
+)
+
+// images des artistes gardées sur disque (~/.groupie-tracker-images/),
+// l'original et les vignettes, nommés par le hash de l'url
+
+var (
+	imageCacheDir  string
+	imageCacheInit sync.Once
+)
+
+// ImageKey renvoie la clé de cache d'une url (hash sha1 en hexadécimal)
+func ImageKey(url string) string {
+	sum := sha1.Sum([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+func imageCachePath(name string) string {
+	imageCacheInit.Do(func() {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			homeDir = "."
+		}
+		imageCacheDir = filepath.Join(homeDir, ".groupie-tracker-images")
+		os.MkdirAll(imageCacheDir, 0o755)
+	})
+	return filepath.Join(imageCacheDir, name)
+}
+
+// FetchImage renvoie l'image d'une url, depuis le disque si elle y est déjà
+func FetchImage(url string) ([]byte, error) {
+	path := imageCachePath(ImageKey(url))
+	if b, err := os.ReadFile(path); err == nil && len(b) > 0 {
+		return b, nil
+	}
+
+	resp, err := doGet(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("image %s: status %d", url, resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// une réponse qui n'est pas une image n'est pas gardée
+	if _, _, err := image.DecodeConfig(bytes.NewReader(b)); err != nil {
+		return nil, fmt.Errorf("image %s illisible: %v", url, err)
+	}
+	os.WriteFile(path, b, 0o644)
+	return b, nil
+}
+
+// FetchThumbnail renvoie l'image réduite pour tenir dans size×size (jpeg, ou
+// png si l'image a de la transparence), calculée une fois puis gardée sur disque
+func FetchThumbnail(url string, size int) ([]byte, error) {
+	base := ImageKey(url) + "-" + strconv.Itoa(size)
+	for _, ext := range []string{".jpg", ".png"} {
+		if b, err := os.ReadFile(imageCachePath(base + ext)); err == nil && len(b) > 0 {
+			return b, nil
+		}
+	}
+
+	b, err := FetchImage(url)
+	if err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("image %s illisible: %v", url, err)
+	}
+
+	// déjà assez petite : on garde l'original
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return b, nil
+	}
+	if w >= h {
+		w, h = size, max(1, h*size/w)
+	} else {
+		w, h = max(1, w*size/h), size
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	// le jpeg n'a pas de transparence : elle deviendrait un fond noir
+	var buf bytes.Buffer
+	ext := ".jpg"
+	if o, ok := src.(interface{ Opaque() bool }); ok && !o.Opaque() {
+		ext = ".png"
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+	os.WriteFile(imageCachePath(base+ext), buf.Bytes(), 0o644)
+	return buf.Bytes(), nil
+}
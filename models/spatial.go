@@ -0,0 +1,128 @@
+package models
+
+import (
+	"math"
+	"sync"
+)
+
+// rayon moyen de la Terre
+const earthRadiusKm = 6371.0
+
+// HaversineKm renvoie la distance orthodromique entre deux points en km
+func HaversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// lieu indexé
+type indexedPlace struct {
+	location  string
+	latitude  float64
+	longitude float64
+}
+
+// ConcertIndex est un index spatial (grille lat/lon) des lieux de concert géocodés
+type ConcertIndex struct {
+	mu      sync.RWMutex
+	cellDeg float64
+	cells   map[[2]int][]indexedPlace
+	places  map[string]indexedPlace
+}
+
+// NewConcertIndex crée un index vide (cellules de 5°)
+func NewConcertIndex() *ConcertIndex {
+	return &ConcertIndex{
+		cellDeg: 5,
+		cells:   make(map[[2]int][]indexedPlace),
+		places:  make(map[string]indexedPlace),
+	}
+}
+
+// cellule contenant le point
+func (ix *ConcertIndex) cellOf(lat, lon float64) [2]int {
+	return [2]int{int(math.Floor(lat / ix.cellDeg)), int(math.Floor(lon / ix.cellDeg))}
+}
+
+// Add ajoute (ou ignore si déjà présent) un lieu géocodé
+func (ix *ConcertIndex) Add(location string, lat, lon float64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if _, exists := ix.places[location]; exists {
+		return
+	}
+	p := indexedPlace{location: location, latitude: lat, longitude: lon}
+	ix.places[location] = p
+	c := ix.cellOf(lat, lon)
+	ix.cells[c] = append(ix.cells[c], p)
+}
+
+// Has indique si le lieu est déjà indexé
+func (ix *ConcertIndex) Has(location string) bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	_, ok := ix.places[location]
+	return ok
+}
+
+// Len renvoie le nombre de lieux indexés
+func (ix *ConcertIndex) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.places)
+}
+
+// DistanceKm renvoie la distance entre un lieu indexé et un point
+func (ix *ConcertIndex) DistanceKm(location string, lat, lon float64) (float64, bool) {
+	ix.mu.RLock()
+	p, ok := ix.places[location]
+	ix.mu.RUnlock()
+	if !ok {
+		return 0, false
+	}
+	return HaversineKm(lat, lon, p.latitude, p.longitude), true
+}
+
+// Within renvoie les lieux situés à moins de radiusKm du point, avec leur distance
+func (ix *ConcertIndex) Within(lat, lon, radiusKm float64) map[string]float64 {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	res := make(map[string]float64)
+
+	// cellules couvertes par le rayon (en longitude ça s'élargit vers les pôles)
+	dLat := radiusKm / 111.32
+	latMin, latMax := math.Max(-90, lat-dLat), math.Min(90, lat+dLat)
+	cos := math.Min(math.Cos(latMin*math.Pi/180), math.Cos(latMax*math.Pi/180))
+	dLon := 180.0
+	if cos > 0.01 {
+		dLon = math.Min(180, radiusKm/(111.32*cos))
+	}
+
+	cMin := ix.cellOf(latMin, lon-dLon)
+	cMax := ix.cellOf(latMax, lon+dLon)
+	lonCells := int(math.Round(360 / ix.cellDeg))
+	seenCols := make(map[int]bool)
+
+	for col := cMin[1]; col <= cMax[1]; col++ {
+		// repli autour de l'antiméridien
+		wrapped := ((col+lonCells/2)%lonCells+lonCells)%lonCells - lonCells/2
+		if seenCols[wrapped] {
+			continue
+		}
+		seenCols[wrapped] = true
+
+		for row := cMin[0]; row <= cMax[0]; row++ {
+			for _, p := range ix.cells[[2]int{row, wrapped}] {
+				if d := HaversineKm(lat, lon, p.latitude, p.longitude); d <= radiusKm {
+					res[p.location] = d
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"sync"
+)
+
+// contours simplifiés des pays (GeoJSON, quelques dizaines de points par pays),
+// embarqués pour avoir un fond de carte sans réseau
+//
+//go:embed world_outlines.json
+var worldOutlinesJSON []byte
+
+// CountryShape est le contour simplifié d'un pays ; chaque anneau est une liste de [lon, lat]
+type CountryShape struct {
+	Code  string
+	Name  string
+	Rings [][][2]float64
+	// boîte englobante (pour écarter vite les pays lors d'un test de point)
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+var (
+	worldOnce      sync.Once
+	worldCountries []CountryShape
+)
+
+// WorldCountries renvoie les contours embarqués (parsés au premier appel)
+func WorldCountries() []CountryShape {
+	worldOnce.Do(func() {
+		var fc struct {
+			Features []struct {
+				Properties struct {
+					Code string `json:"iso_a2"`
+					Name string `json:"name"`
+				} `json:"properties"`
+				Geometry struct {
+					Coordinates [][][][2]float64 `json:"coordinates"`
+				} `json:"geometry"`
+			} `json:"features"`
+		}
+		if err := json.Unmarshal(worldOutlinesJSON, &fc); err != nil {
+			log.Printf("Contours des pays illisibles: %v\n", err)
+			return
+		}
+
+		for _, f := range fc.Features {
+			c := CountryShape{Code: f.Properties.Code, Name: f.Properties.Name,
+				MinLon: 180, MinLat: 90, MaxLon: -180, MaxLat: -90}
+			// on ne garde que l'anneau extérieur de chaque polygone
+			for _, poly := range f.Geometry.Coordinates {
+				if len(poly) == 0 {
+					continue
+				}
+				ring := poly[0]
+				for _, p := range ring {
+					c.MinLon, c.MaxLon = min(c.MinLon, p[0]), max(c.MaxLon, p[0])
+					c.MinLat, c.MaxLat = min(c.MinLat, p[1]), max(c.MaxLat, p[1])
+				}
+				c.Rings = append(c.Rings, ring)
+			}
+			worldCountries = append(worldCountries, c)
+		}
+	})
+	return worldCountries
+}
+
+// Contains indique si le point est dans un des anneaux du pays
+func (c *CountryShape) Contains(lat, lon float64) bool {
+	if lon < c.MinLon || lon > c.MaxLon || lat < c.MinLat || lat > c.MaxLat {
+		return false
+	}
+	for _, ring := range c.Rings {
+		if pointInRing(ring, lon, lat) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountryAt renvoie le pays contenant le point, nil si aucun (mer, pays absent)
+func CountryAt(lat, lon float64) *CountryShape {
+	countries := WorldCountries()
+	for i := range countries {
+		if countries[i].Contains(lat, lon) {
+			return &countries[i]
+		}
+	}
+	return nil
+}
+
+// test pair-impair (ray casting)
+func pointInRing(ring [][2]float64, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
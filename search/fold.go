@@ -0,0 +1,41 @@
+// Package search cherche un texte libre dans des champs pondérés : accents
+// ignorés, mots dans n'importe quel ordre, fautes de frappe tolérées et
+// résultats classés par pertinence.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// lettres qui ne se décomposent pas en lettre + accent
+var foldSpecial = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ł': "l", 'ı': "i", 'þ': "th",
+}
+
+// Fold met en minuscules et retire les accents ("Beyoncé" -> "beyonce")
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue // accent combinant
+		}
+		r = unicode.ToLower(r)
+		if rep, ok := foldSpecial[r]; ok {
+			b.WriteString(rep)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Tokens découpe un texte replié en mots (tout ce qui n'est ni lettre ni chiffre sépare)
+func Tokens(s string) []string {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
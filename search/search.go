@@ -0,0 +1,96 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Field est un texte où chercher, avec son poids (un nom compte plus qu'une date)
+type Field struct {
+	Text   string
+	Weight float64
+	tokens []string
+}
+
+// NewField prépare un champ (texte replié et découpé une seule fois)
+func NewField(text string, weight float64) Field {
+	return Field{Text: text, Weight: weight, tokens: Tokens(text)}
+}
+
+// Query est un texte recherché, préparé une fois pour tous les documents
+type Query struct {
+	folded string
+	tokens []string
+}
+
+// NewQuery prépare une recherche
+func NewQuery(text string) Query {
+	return Query{folded: strings.Join(Tokens(text), " "), tokens: Tokens(text)}
+}
+
+// Empty indique une recherche sans mot (tout correspond)
+func (q Query) Empty() bool { return len(q.tokens) == 0 }
+
+// Score renvoie la pertinence des champs pour la recherche ; 0 si un des mots
+// cherchés ne se trouve dans aucun champ
+func (q Query) Score(fields ...Field) float64 {
+	if q.Empty() {
+		return 0
+	}
+	total := 0.0
+	for _, qt := range q.tokens {
+		best := 0.0
+		for _, f := range fields {
+			for _, t := range f.tokens {
+				best = max(best, tokenScore(qt, t)*f.Weight)
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	score := total / float64(len(q.tokens))
+
+	// bonus quand les mots se suivent comme dans la recherche ("freddie mercury")
+	for _, f := range fields {
+		if len(q.tokens) > 1 && strings.Contains(strings.Join(f.tokens, " "), q.folded) {
+			score += 0.5 * f.Weight
+			break
+		}
+	}
+	return score
+}
+
+// pertinence d'un mot trouvé : exact > début de mot > dans le mot > avec fautes
+func tokenScore(q, t string) float64 {
+	switch {
+	case q == t:
+		return 1
+	case strings.HasPrefix(t, q):
+		return 0.8 + 0.1*float64(len(q))/float64(len(t))
+	case strings.Contains(t, q):
+		return 0.5
+	}
+
+	// fautes de frappe : seulement sur des mots (pas sur les années)
+	typos := maxTypos(q)
+	if typos == 0 || !strings.ContainsFunc(q, unicode.IsLetter) {
+		return 0
+	}
+	if d := Distance(q, t); d <= typos {
+		return 0.6 * (1 - float64(d)/float64(len([]rune(q))+1))
+	}
+	// début de mot mal tapé ("qeen" dans "queens") : on compare aux premières
+	// lettres du mot, une de plus pour une lettre oubliée
+	rq, rt := []rune(q), []rune(t)
+	for _, n := range []int{len(rq), len(rq) + 1} {
+		if n >= len(rt) {
+			break
+		}
+		if d := Distance(q, string(rt[:n])); d <= typos {
+			return 0.4 * (1 - float64(d)/float64(len(rq)+1))
+		}
+	}
+	return 0
+}
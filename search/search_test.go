@@ -0,0 +1,101 @@
+package search
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Beyoncé", "beyonce"},
+		{"MOTÖRHEAD", "motorhead"},
+		{"Straße", "strasse"},
+		{"São Paulo", "sao paulo"},
+		{"queen", "queen"},
+	}
+	for _, tt := range tests {
+		if got := Fold(tt.in); got != tt.want {
+			t.Errorf("Fold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"queen", "queen", 0},
+		{"qeen", "queen", 1},  // lettre oubliée
+		{"qeuen", "queen", 1}, // inversion de deux lettres voisines
+		{"metallcia", "metallica", 1},
+		{"pink", "punk", 1}, // substitution
+		{"", "abc", 3},
+		{"abc", "", 3},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestScoreMatches(t *testing.T) {
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"beyonce", "Beyoncé", true},
+		{"BEYONCÉ", "beyonce", true},
+		{"qeen", "Queen", true},
+		{"qeen", "Queens of the Stone Age", true},
+		{"mercury freddie", "Freddie Mercury", true}, // mots dans n'importe quel ordre
+		{"1973", "1973", true},
+		{"1974", "1973", false}, // pas de faute tolérée sur les nombres
+		{"queen xyz", "Queen", false},
+		{"abba", "Metallica", false},
+	}
+	for _, tt := range tests {
+		got := NewQuery(tt.query).Score(NewField(tt.text, 1)) > 0
+		if got != tt.match {
+			t.Errorf("%q in %q: match = %v, want %v", tt.query, tt.text, got, tt.match)
+		}
+	}
+}
+
+func TestScoreRanking(t *testing.T) {
+	names := []string{"Queens of the Stone Age", "Qeen Tribute", "Queen", "Pink Floyd", "The Queen Is Dead"}
+	q := NewQuery("queen")
+
+	type result struct {
+		name  string
+		score float64
+	}
+	var results []result
+	for _, n := range names {
+		if s := q.Score(NewField(n, 1)); s > 0 {
+			results = append(results, result{n, s})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+
+	// mot exact, puis début de mot, puis faute de frappe ; Pink Floyd écarté
+	want := []string{"Queen", "The Queen Is Dead", "Queens of the Stone Age", "Qeen Tribute"}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results %v, want %v", len(results), results, want)
+	}
+	for i, w := range want {
+		if results[i].name != w {
+			t.Errorf("rank %d = %q, want %q (all: %v)", i, results[i].name, w, results)
+		}
+	}
+}
+
+func TestScoreWeights(t *testing.T) {
+	q := NewQuery("london")
+	name := q.Score(NewField("London Grammar", 3))
+	location := q.Score(NewField("london-uk", 1))
+	if name <= location {
+		t.Errorf("name score %v should beat location score %v", name, location)
+	}
+}
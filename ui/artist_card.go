@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// taille de l'image sur une carte artiste
+var artistCardImageSize = fyne.NewSize(200, 200)
+
+// carte artiste réutilisable par la grille : le gabarit est construit une fois,
+// setArtist ne fait que changer les textes et l'image
+type artistCard struct {
+	widget.BaseWidget
+
+	content  *fyne.Container
+	img      *canvas.Image
+	name     *canvas.Text
+	members  *canvas.Text
+	created  *canvas.Text
+	distance *canvas.Text
+	concerts *canvas.Text
+	star     *widget.Button
+	details  *widget.Button
+
+	artist   models.Artist
+	imageOK  bool // image de artist affichée (pas l'attente ni l'échec)
+	onSelect func(models.Artist)
+}
+
+func newArtistCard(onSelect func(models.Artist)) *artistCard {
+	c := &artistCard{onSelect: onSelect}
+
+	// image de l'artiste
+	c.img = canvas.NewImageFromResource(imagePlaceholder())
+	c.img.FillMode = canvas.ImageFillContain
+	c.img.SetMinSize(artistCardImageSize)
+
+	// nom centré sous l'image (color choisi selon contraste)
+	captionBg := canvas.NewRectangle(CardBgLight)
+	c.name = canvas.NewText("", ContrastColor(CardBgLight))
+	c.name.TextStyle = fyne.TextStyle{Bold: true}
+	c.name.Alignment = fyne.TextAlignCenter
+
+	// petit fond sous le nom
+	caption := container.NewStack(
+		captionBg,
+		container.NewPadded(container.NewCenter(c.name)),
+	)
+
+	// infos rapides (texte rendu selon contraste du fond de la carte)
+	c.members = canvas.NewText("", ContrastColor(CardBg))
+	c.members.Alignment = fyne.TextAlignCenter
+	c.created = canvas.NewText("", ContrastColor(CardBg))
+	c.created.Alignment = fyne.TextAlignCenter
+
+	// distance au concert le plus proche (vide si position inconnue)
+	c.distance = canvas.NewText("", AccentCyan)
+	c.distance.Alignment = fyne.TextAlignCenter
+
+	// concerts dans la période du filtre de dates (vide sans filtre)
+	c.concerts = canvas.NewText("", AccentPink)
+	c.concerts.Alignment = fyne.TextAlignCenter
+
+	// étoile des favoris en haut à droite de l'image
+	c.star = newFavoriteButton(func() {
+		favorites.toggle(c.artist.ID)
+	})
+	picture := container.NewStack(c.img, container.NewVBox(
+		container.NewHBox(layout.NewSpacer(), c.star),
+	))
+
+	// bouton pour ouvrir la fiche
+	c.details = widget.NewButton(T().ShowDetails, func() {
+		if c.onSelect != nil {
+			c.onSelect(c.artist)
+		}
+	})
+	c.details.Importance = widget.HighImportance
+
+	// fond avec bordure (utilise couleurs sombres pour lisibilité)
+	bg := canvas.NewRectangle(CardBg)
+	c.content = container.NewStack(bg, container.NewPadded(container.NewVBox(
+		picture,
+		caption,
+		c.members,
+		c.created,
+		c.distance,
+		c.concerts,
+		container.NewCenter(c.details),
+	)))
+
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// affiche un artiste dans la carte (appelé à chaque recyclage)
+func (c *artistCard) setArtist(artist models.Artist, distance, concerts string) {
+	imageChanged := artist.Image != c.artist.Image
+	c.artist = artist
+
+	c.name.Text = artist.Name
+	c.members.Text = fmt.Sprintf("%d "+T().Members, len(artist.Members))
+	c.created.Text = fmt.Sprintf(T().Created, artist.CreationDate)
+	c.distance.Text = distance
+	c.concerts.Text = concerts
+	c.name.Refresh()
+	c.members.Refresh()
+	c.created.Refresh()
+	c.distance.Refresh()
+	c.concerts.Refresh()
+	setFavoriteButton(c.star, artist.ID)
+	// cartes recyclées après un changement de langue
+	if c.details.Text != T().ShowDetails {
+		c.details.SetText(T().ShowDetails)
+	}
+
+	// autre artiste, ou image en échec redemandée (le chargeur espace les essais)
+	if imageChanged || !c.imageOK {
+		if imageChanged {
+			c.imageOK = false
+			c.img.Resource = imagePlaceholder()
+			c.img.Refresh()
+		}
+		url := artist.Image
+		artistImages.load(url, cardThumbnailSize, func(res fyne.Resource) {
+			// la carte a pu être recyclée pour un autre artiste entre-temps
+			if c.artist.Image != url {
+				return
+			}
+			c.imageOK = res != imageError()
+			c.img.Resource = res
+			c.img.Refresh()
+		})
+	}
+}
+
+func (c *artistCard) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
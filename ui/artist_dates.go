@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// période prédéfinie du filtre de dates : bornes calculées depuis aujourd'hui
+type datePreset struct {
+	label  func() string
+	bounds func(now time.Time) (from, to time.Time)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+var datePresets = []datePreset{
+	{func() string { return T().PresetNext3Months }, func(now time.Time) (time.Time, time.Time) {
+		return startOfDay(now), startOfDay(now).AddDate(0, 3, 0)
+	}},
+	{func() string { return T().PresetThisYear }, func(now time.Time) (time.Time, time.Time) {
+		return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC), time.Date(now.Year(), 12, 31, 0, 0, 0, 0, time.UTC)
+	}},
+	{func() string { return T().PresetLastYear }, func(now time.Time) (time.Time, time.Time) {
+		return time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(now.Year()-1, 12, 31, 0, 0, 0, 0, time.UTC)
+	}},
+	{func() string { return T().PresetAllDates }, func(time.Time) (time.Time, time.Time) {
+		return time.Time{}, time.Time{}
+	}},
+}
+
+// filtre sur les dates de concert : deux sélecteurs de date et des périodes toutes faites ;
+// renvoie aussi la fonction qui remet les widgets à jour depuis l'état
+func (l *ArtistList) createDateFilter() (fyne.CanvasObject, func()) {
+	fromEntry := widget.NewDateEntry()
+	toEntry := widget.NewDateEntry()
+	syncing := false
+
+	labels := make([]string, len(datePresets))
+	for i, p := range datePresets {
+		labels[i] = p.label()
+	}
+	presets := widget.NewSelect(labels, nil)
+	presets.PlaceHolder = T().DatePresets
+
+	sync := func() {
+		syncing = true
+		defer func() { syncing = false }()
+		setDateEntry(fromEntry, l.dateFrom)
+		setDateEntry(toEntry, l.dateTo)
+	}
+
+	// une date tapée ou choisie au calendrier
+	changed := func() {
+		if syncing {
+			return
+		}
+		l.dateFrom = dateEntryValue(fromEntry)
+		l.dateTo = dateEntryValue(toEntry)
+		presets.ClearSelected()
+		l.filtersChanged()
+	}
+	fromEntry.OnChanged = func(*time.Time) { changed() }
+	toEntry.OnChanged = func(*time.Time) { changed() }
+
+	presets.OnChanged = func(string) {
+		i := presets.SelectedIndex()
+		if i < 0 || syncing {
+			return
+		}
+		l.dateFrom, l.dateTo = datePresets[i].bounds(time.Now())
+		sync()
+		l.filtersChanged()
+	}
+
+	return container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel(T().DateFrom), fromEntry,
+			widget.NewLabel(T().DateTo), toEntry,
+		),
+		presets,
+	), sync
+}
+
+func setDateEntry(e *widget.DateEntry, t time.Time) {
+	if t.IsZero() {
+		e.SetDate(nil)
+		return
+	}
+	e.SetDate(&t)
+}
+
+// date du widget, zéro si vide ou illisible
+func dateEntryValue(e *widget.DateEntry) time.Time {
+	if e.Date == nil || e.Text == "" {
+		return time.Time{}
+	}
+	return startOfDay(*e.Date)
+}
+
+// nombre de concerts dans la période, affiché sur la carte ("" sans filtre de dates)
+func (l *ArtistList) concertCountText(a models.Artist) string {
+	if l.dateFrom.IsZero() && l.dateTo.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(T().ConcertsInRangeFmt, l.concertsInRange(a))
+}
@@ -3,16 +3,15 @@ package ui
 import (
 	"fmt"
 	"groupie-tracker/models"
+	"groupie-tracker/search"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/layout"
-	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -23,17 +22,57 @@ type ArtistList struct {
 	allLocations   []string
 	onSelect       func(models.Artist)
 	onShowMap      func()
+	onFavorites    func()
 	searchText     string
-	grid           *fyne.Container
+	searchScores   map[int]float64 // pertinence par id d'artiste pour la recherche en cours
+	searchEntry    *suggestEntry
+	queryErrors    *widget.RichText // morceaux de requête mal formés
+	syncingQuery   bool             // texte réécrit depuis les filtres, pas depuis le clavier
 	searchDebounce *time.Timer
+	queryGen       int // incrémenté à chaque saisie ou annulation : une requête en retard est ignorée
+
+	// grille virtualisée : seules les cartes visibles existent
+	grid    *widget.GridWrap
+	visible []models.Artist
+	empty   *widget.Label
 
 	// filtres mémorisés
-	creationMin  int
-	creationMax  int
-	albumMin     int
-	albumMax     int
-	memberCounts map[int]bool
-	selectedLocs map[string]bool
+	creationMin       int
+	creationMax       int
+	albumMin          int
+	albumMax          int
+	memberCounts      map[int]bool // nombre de membres -> coché
+	memberOptions     []int        // nombres de membres présents dans les données
+	memberChips       *memberChips
+	locationTree      *locationTree
+	selectedLocs      map[string]bool
+	favoritesOnly     bool
+	syncFilters       func() // remet les widgets du panneau à jour depuis ces champs
+	syncFavoritesOnly func() // case « favoris seulement » remise à jour depuis favoritesOnly
+
+	// curseurs des années (histogramme mis à jour à chaque filtrage)
+	creationSlider *rangeSlider
+	albumSlider    *rangeSlider
+	dateFrom       time.Time // concerts entre ces dates (zéro : pas de borne)
+	dateTo         time.Time
+
+	// tri mémorisé
+	sortKey  artistSortKey
+	sortDesc bool
+
+	// concerts près de moi
+	win           *Window
+	home          *homeLocation
+	nearbyEnabled bool
+	nearbyRadius  float64
+	nearbyIndex   *models.ConcertIndex
+	nearbyLoading bool
+	nearbyStatus  *widget.Label
+
+	// vue gardée entre les navigations, refaite seulement au changement de langue
+	root      *fyne.Container
+	accordion *widget.Accordion
+	lang      string // langue des widgets affichés
 }
 
 // build liste artistes
@@ -43,11 +82,23 @@ func NewArtistList(artists []models.Artist, onSelect func(models.Artist), onShow
 
 // build liste artistes avec window pour bouton langue
 func NewArtistListWithWindow(win *Window, artists []models.Artist, onSelect func(models.Artist), onShowMap func()) *fyne.Container {
+	return NewArtistListView(win, artists, onSelect, onShowMap, nil).root
+}
+
+// liste à garder entre deux navigations : recherche, filtres, défilement et
+// relations chargées restent en place ; Content() la remet à l'écran.
+// onFavorites ouvre la page des favoris (pas de bouton si nil)
+func NewArtistListView(win *Window, artists []models.Artist, onSelect func(models.Artist), onShowMap, onFavorites func()) *ArtistList {
 	list := &ArtistList{
-		artists: artists, onShowMap: onShowMap, onSelect: onSelect,
+		artists: artists, onShowMap: onShowMap, onSelect: onSelect, onFavorites: onFavorites,
 		memberCounts: make(map[int]bool),
 		selectedLocs: make(map[string]bool),
+		win:          win,
+		home:         getHomeLocation(),
+		nearbyRadius: getNearbyRadius(),
+		nearbyIndex:  models.NewConcertIndex(),
 	}
+	list.sortKey, list.sortDesc = getArtistSort()
 
 	// bornes min/max pour filtres
 	list.creationMin, list.creationMax = getCreationYearRange(artists)
@@ -57,8 +108,8 @@ func NewArtistListWithWindow(win *Window, artists []models.Artist, onSelect func
 	list.allLocations = extractAllLocations(artists)
 
 	// par défaut on coche tout
-	for i := 1; i <= 8; i++ {
-		list.memberCounts[i] = true
+	for _, n := range memberCountOptions(artists) {
+		list.memberCounts[n] = true
 	}
 	// on ajoute les lieux initiaux
 	for _, loc := range list.allLocations {
@@ -66,309 +117,449 @@ func NewArtistListWithWindow(win *Window, artists []models.Artist, onSelect func
 	}
 	// on coche tout pour ne rien filtrer au début, on ajustera après enrichissement
 
-	// on garde le callback pour rafraîchir les cases
-	var updateLocationChecks func(string)
-
 	// charge les relations en async
 	go func() {
 		relations, err := models.FetchRelations()
+		// lieux uniques et dates de chaque artiste, préparés ici puis posés
+		// sur les artistes depuis le thread UI (la liste les lit en filtrant)
+		locsByID := make(map[int][]string)
+		datesByID := make(map[int][]string)
+		timesByID := make(map[int][]time.Time)
 		if err != nil {
 			// si ça rate on continue quand même
 			log.Println("Erreur lors du chargement des relations:", err)
 		} else if relations != nil {
-			// enrichit chaque artiste avec ses lieux
-			for i := range list.artists {
-				for _, rel := range relations.Index {
-					if rel.ID == list.artists[i].ID {
-						// extrait les lieux uniques depuis datesLocations
-						locSet := make(map[string]bool)
-						for loc := range rel.DatesLocations {
-							locSet[loc] = true
-						}
-						for loc := range locSet {
-							list.artists[i].LocationsList = append(list.artists[i].LocationsList, loc)
+			for _, rel := range relations.Index {
+				for loc, dates := range rel.DatesLocations {
+					locsByID[rel.ID] = append(locsByID[rel.ID], loc)
+					// une date par concert, pour le tri
+					datesByID[rel.ID] = append(datesByID[rel.ID], dates...)
+					for _, d := range dates {
+						if t, err := models.ParseConcertDate(d); err == nil {
+							timesByID[rel.ID] = append(timesByID[rel.ID], t)
 						}
-						break
 					}
 				}
 			}
+		}
 
-			// on met à jour la liste des lieux
-			list.allLocations = extractAllLocations(list.artists)
+		// on rafraîchit la grille une fois
+		fyne.Do(func() {
+			if relations != nil {
+				// enrichit chaque artiste avec ses lieux
+				for i := range list.artists {
+					id := list.artists[i].ID
+					if locs, ok := locsByID[id]; ok {
+						list.artists[i].LocationsList = append(list.artists[i].LocationsList, locs...)
+						list.artists[i].DatesList = append(list.artists[i].DatesList, datesByID[id]...)
+						list.artists[i].ConcertTimes = append(list.artists[i].ConcertTimes, timesByID[id]...)
+					}
+				}
 
-			// on coche tous les lieux
-			for _, loc := range list.allLocations {
-				list.selectedLocs[loc] = true
+				// on met à jour la liste des lieux et on les coche tous
+				list.allLocations = extractAllLocations(list.artists)
+				for _, loc := range list.allLocations {
+					list.selectedLocs[loc] = true
+				}
 			}
 
-			// rafraîchit les cases si déjà affichées
-			if updateLocationChecks != nil {
-				updateLocationChecks("")
+			// distances sur les cartes si une position est connue
+			if list.home != nil {
+				list.ensureNearbyIndex()
+			}
+			// puces des membres refaites si les données ont changé
+			if list.memberChips != nil {
+				list.memberChips.rebuild()
+			}
+			// arbre des lieux refait avec les lieux chargés
+			if list.locationTree != nil {
+				list.locationTree.setFilter(list.locationTree.filter)
+			}
+			// reprend les filtres de la dernière session si demandé
+			if state, ok := getLastFilterState(); ok && getRestoreLastFilters() {
+				list.applyFilterState(state)
 			}
-		}
-
-		// on rafraîchit la grille une fois
-		fyne.Do(func() {
 			list.rebuildGrid()
+
+			// état des filtres gardé pour la session suivante, une fois à la fermeture
+			if app := fyne.CurrentApp(); app != nil {
+				app.Lifecycle().SetOnStopped(func() {
+					saveLastFilterState(list.filterState())
+				})
+			}
 		})
 	}()
 
-	// barre de recherche
-	searchEntry := widget.NewEntry()
+	// grille d'artistes : les cartes sont recyclées pendant le défilement,
+	// filtrer ne change que la liste des artistes affichés
+	list.grid = widget.NewGridWrap(
+		func() int { return len(list.visible) },
+		func() fyne.CanvasObject { return newArtistCard(list.onSelect) },
+		func(id widget.GridWrapItemID, obj fyne.CanvasObject) {
+			if id >= len(list.visible) {
+				return
+			}
+			artist := list.visible[id]
+			obj.(*artistCard).setArtist(artist, list.distanceText(artist), list.concertCountText(artist))
+		},
+	)
+
+	// message quand rien ne correspond
+	list.empty = widget.NewLabel(T().NoResults)
+	list.empty.Alignment = fyne.TextAlignCenter
+	list.empty.Hide()
+
+	list.root = container.NewStack()
+	list.buildView()
+
+	// étoiles et filtre des favoris suivent chaque changement
+	favorites.onChanged(list.rebuildGrid)
+
+	// on construit la grille dès le départ
+	list.rebuildGrid()
+
+	return list
+}
+
+// contenu à afficher, retraduit si la langue a changé depuis
+func (l *ArtistList) Content() fyne.CanvasObject {
+	// position changée ailleurs (ex: depuis la carte) : distances et filtre la suivent
+	homeChanged := false
+	if h := getHomeLocation(); !sameHome(h, l.home) {
+		l.home = h
+		homeChanged = true
+		if h != nil {
+			l.ensureNearbyIndex()
+		}
+	}
+	// le panneau est refait pour la langue ou pour afficher la nouvelle position
+	if l.lang != CurrentLang || homeChanged {
+		l.Retranslate()
+	}
+	return l.root
+}
+
+func sameHome(a, b *homeLocation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// passe les widgets dans la langue courante sans perdre recherche, filtres,
+// sections ouvertes ni défilement ; la grille est gardée telle quelle
+func (l *ArtistList) Retranslate() {
+	text := l.searchEntry.Text
+	offset := l.grid.GetScrollOffset()
+	var open []int
+	for i, item := range l.accordion.Items {
+		if item.Open {
+			open = append(open, i)
+		}
+	}
+
+	l.empty.SetText(T().NoResults)
+	l.buildView()
+
+	l.syncingQuery = true
+	l.searchEntry.SetText(text)
+	l.syncingQuery = false
+	l.showQueryErrors(text, parseArtistQuery(text))
+	for _, i := range open {
+		l.accordion.Open(i)
+	}
+	l.syncFilters()
+	l.rebuildGrid()
+	l.grid.ScrollToOffset(offset)
+}
+
+// construit tout ce qui entoure la grille, dans la langue courante
+func (l *ArtistList) buildView() {
+	// barre de recherche : texte libre et filtres clé:valeur (members:4 created:>1990...)
+	searchEntry := newSuggestEntry()
 	searchEntry.SetPlaceHolder(T().SearchPlaceholder)
+	l.searchEntry = searchEntry
+	l.queryErrors = widget.NewRichText()
+	l.queryErrors.Hide()
+	suggest := newArtistSuggest(l, searchEntry)
 	searchEntry.OnChanged = func(text string) {
-		list.searchText = strings.ToLower(text)
-		if list.searchDebounce != nil {
-			list.searchDebounce.Stop()
+		if l.syncingQuery {
+			return
 		}
-		list.searchDebounce = time.AfterFunc(200*time.Millisecond, func() {
+		query := parseArtistQuery(text)
+		suggest.update(query.text)
+		l.cancelPendingQuery()
+		gen := l.queryGen
+		l.searchDebounce = time.AfterFunc(200*time.Millisecond, func() {
 			fyne.Do(func() {
-				list.rebuildGrid()
+				if gen != l.queryGen {
+					return // filtres changés entre-temps
+				}
+				l.searchDebounce = nil
+				l.applyQuery(query)
+				l.showQueryErrors(text, query)
+				if l.syncFilters != nil {
+					l.syncFilters()
+				}
+				l.rebuildGrid()
 			})
 		})
 	}
 
 	// panneau de filtres
-	filterPanel, _, updateLocationChecksFunc := list.createFilterPanel()
-	updateLocationChecks = updateLocationChecksFunc
-
-	// grille d'artistes (4 colonnes)
-	grid := container.New(layout.NewGridLayout(4))
-	list.grid = grid
-
-	// on construit la grille dès le départ
-	list.rebuildGrid()
-
-	// conteneur scroll
-	scroll := container.NewScroll(grid)
+	filterPanel := l.createFilterPanel()
 
 	// bouton pour ouvrir la carte
-	mapButton := widget.NewButton(T().ShowMap, list.onShowMap)
+	mapButton := widget.NewButton(T().ShowMap, l.onShowMap)
 	mapButton.Importance = widget.HighImportance
 
 	// barre de boutons
 	topButtons := container.NewHBox()
-	if win != nil && win.LangButton != nil {
-		topButtons.Add(win.LangButton)
+	if l.win != nil && l.win.LangButton != nil {
+		topButtons.Add(l.win.LangButton)
 	}
 	topButtons.Add(mapButton)
+	if l.onFavorites != nil {
+		topButtons.Add(widget.NewButtonWithIcon(T().Favorites, starFilled, l.onFavorites))
+	}
+
+	// n'afficher que les favoris
+	favoritesCheck := widget.NewCheck(T().FavoritesOnly, func(checked bool) {
+		l.favoritesOnly = checked
+		l.rebuildGrid()
+	})
+	favoritesCheck.Checked = l.favoritesOnly
+	l.syncFavoritesOnly = func() { favoritesCheck.SetChecked(l.favoritesOnly) }
 
-	return container.NewBorder(
+	l.root.Objects = []fyne.CanvasObject{container.NewBorder(
 		container.NewVBox(
 			widget.NewLabelWithStyle(T().WindowTitle, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 			container.NewCenter(topButtons),
-			searchEntry,
-			filterPanel,
-			widget.NewSeparator(),
+			container.NewBorder(nil, nil, nil, container.NewHBox(favoritesCheck, l.createSortBar()), searchEntry),
+			l.queryErrors,
 		),
 		nil,
 		nil,
 		nil,
-		scroll,
-	)
+		// suggestions par-dessus les filtres et la grille, juste sous la recherche
+		suggest.over(container.NewBorder(
+			container.NewVBox(filterPanel, widget.NewSeparator()),
+			nil, nil, nil,
+			container.NewStack(l.grid, container.NewVBox(l.empty)),
+		)),
+	)}
+	l.lang = CurrentLang
+	l.root.Refresh()
 }
 
 // panneau filtres
-func (l *ArtistList) createFilterPanel() (*fyne.Container, *fyne.Container, func(string)) {
-	// filtre date de création
+func (l *ArtistList) createFilterPanel() *fyne.Container {
+	// filtres sur les années : curseur à deux poignées, filtrage immédiat
 	creationLabel := widget.NewLabel(fmt.Sprintf(T().CreationYear+": %d - %d", l.creationMin, l.creationMax))
-	creationMinEntry := widget.NewEntry()
-	creationMinEntry.SetText(strconv.Itoa(l.creationMin))
-	creationMaxEntry := widget.NewEntry()
-	creationMaxEntry.SetText(strconv.Itoa(l.creationMax))
-
-	creationApply := widget.NewButton(T().Filters, func() {
-		min, _ := strconv.Atoi(creationMinEntry.Text)
-		max, _ := strconv.Atoi(creationMaxEntry.Text)
-		if min > 0 && max > 0 && min <= max {
-			l.creationMin = min
-			l.creationMax = max
-			creationLabel.SetText(fmt.Sprintf(T().CreationYear+": %d - %d", min, max))
-			l.rebuildGrid()
-		}
+	minC, maxC := getCreationYearRange(l.artists)
+	l.creationSlider = newRangeSlider(minC, maxC, func(low, high int) {
+		l.creationMin, l.creationMax = low, high
+		creationLabel.SetText(fmt.Sprintf(T().CreationYear+": %d - %d", low, high))
+		l.filtersChanged()
 	})
+	l.creationSlider.setRange(l.creationMin, l.creationMax)
+	creationFilter := container.NewVBox(creationLabel, l.creationSlider)
 
-	creationFilter := container.NewVBox(
-		creationLabel,
-		container.NewGridWithColumns(3,
-			widget.NewLabel(T().Min+":"),
-			creationMinEntry,
-			widget.NewLabel(""),
-		),
-		container.NewGridWithColumns(3,
-			widget.NewLabel(T().Max+":"),
-			creationMaxEntry,
-			creationApply,
-		),
-	)
-
-	// filtre sur l'année du premier album
 	albumLabel := widget.NewLabel(fmt.Sprintf(T().FirstAlbum+": %d - %d", l.albumMin, l.albumMax))
-	albumMinEntry := widget.NewEntry()
-	albumMinEntry.SetText(strconv.Itoa(l.albumMin))
-	albumMaxEntry := widget.NewEntry()
-	albumMaxEntry.SetText(strconv.Itoa(l.albumMax))
-
-	albumApply := widget.NewButton(T().Filters, func() {
-		min, _ := strconv.Atoi(albumMinEntry.Text)
-		max, _ := strconv.Atoi(albumMaxEntry.Text)
-		if min > 0 && max > 0 && min <= max {
-			l.albumMin = min
-			l.albumMax = max
-			albumLabel.SetText(fmt.Sprintf(T().FirstAlbum+": %d - %d", min, max))
-			l.rebuildGrid()
-		}
+	minA, maxA := getFirstAlbumYearRange(l.artists)
+	l.albumSlider = newRangeSlider(minA, maxA, func(low, high int) {
+		l.albumMin, l.albumMax = low, high
+		albumLabel.SetText(fmt.Sprintf(T().FirstAlbum+": %d - %d", low, high))
+		l.filtersChanged()
 	})
-
-	albumFilter := container.NewVBox(
-		albumLabel,
-		container.NewGridWithColumns(3,
-			widget.NewLabel(T().Min+":"),
-			albumMinEntry,
-			widget.NewLabel(""),
-		),
-		container.NewGridWithColumns(3,
-			widget.NewLabel(T().Max+":"),
-			albumMaxEntry,
-			albumApply,
-		),
-	)
-
-	// filtre par nombre de membres
-	memberChecks := make([]*widget.Check, 0)
-	memberContainer := container.NewVBox()
-
-	for i := 1; i <= 8; i++ {
-		count := i
-		check := widget.NewCheck(fmt.Sprintf("%d "+T().Members, count), func(checked bool) {
-			l.memberCounts[count] = checked
-			l.rebuildGrid()
-		})
-		check.Checked = true
-		memberChecks = append(memberChecks, check)
-		memberContainer.Add(check)
+	l.albumSlider.setRange(l.albumMin, l.albumMax)
+	albumFilter := container.NewVBox(albumLabel, l.albumSlider)
+
+	// filtre par nombre de membres (puces tirées des données)
+	l.memberChips = l.createMemberFilter()
+
+	// filtre concerts proches
+	nearbyFilter, resetNearby := l.createNearbyFilter()
+
+	// filtre sur les dates de concert
+	dateFilter, syncDates := l.createDateFilter()
+
+	// filtre des lieux : arbre continent → pays → ville
+	var locationFilter fyne.CanvasObject
+	l.locationTree, locationFilter = l.createLocationTree()
+
+	// remet chaque widget à jour depuis l'état des filtres
+	l.syncFilters = func() {
+		l.creationSlider.setRange(l.creationMin, l.creationMax)
+		l.albumSlider.setRange(l.albumMin, l.albumMax)
+		creationLabel.SetText(fmt.Sprintf(T().CreationYear+": %d - %d", l.creationMin, l.creationMax))
+		albumLabel.SetText(fmt.Sprintf(T().FirstAlbum+": %d - %d", l.albumMin, l.albumMax))
+		l.memberChips.sync()
+		l.locationTree.tree.Refresh()
+		syncDates()
 	}
 
-	// filtre des lieux (checkbox + recherche)
-	locationSearch := widget.NewEntry()
-	locationSearch.SetPlaceHolder(T().Search + " " + T().Location + "...")
-
-	locationChecks := container.NewVBox()
-	updateLocationChecks := func(filter string) {
-		locationChecks.Objects = nil
-		filter = strings.ToLower(filter)
-
-		// parcourt les lieux et ajoute les cases
-		for _, loc := range l.allLocations {
-			if filter != "" && !strings.Contains(strings.ToLower(loc), filter) {
-				continue
-			}
-
-			locCopy := loc
-			// on garde l'état déjà coché
-			isChecked := l.selectedLocs[locCopy]
-
-			check := widget.NewCheck(loc, func(checked bool) {
-				l.selectedLocs[locCopy] = checked
-				l.rebuildGrid()
-			})
-			check.Checked = isChecked
-			locationChecks.Add(check)
-		}
-		locationChecks.Refresh()
-	}
-
-	locationSearch.OnChanged = func(text string) {
-		updateLocationChecks(text)
-	}
-	updateLocationChecks("")
-
-	locationScroll := container.NewScroll(locationChecks)
-	locationScroll.SetMinSize(fyne.NewSize(300, 150))
-
-	locationFilter := container.NewVBox(
-		locationSearch,
-		locationScroll,
-	)
-
 	// bouton pour tout réinitialiser
 	resetBtn := widget.NewButton("🔄 "+T().ResetFilters, func() {
 		// reset des dates
-		minC, maxC := getCreationYearRange(l.artists)
-		minA, maxA := getFirstAlbumYearRange(l.artists)
-		l.creationMin = minC
-		l.creationMax = maxC
-		l.albumMin = minA
-		l.albumMax = maxA
-
-		creationMinEntry.SetText(strconv.Itoa(minC))
-		creationMaxEntry.SetText(strconv.Itoa(maxC))
-		albumMinEntry.SetText(strconv.Itoa(minA))
-		albumMaxEntry.SetText(strconv.Itoa(maxA))
-		creationLabel.SetText(fmt.Sprintf(T().CreationYear+": %d - %d", minC, maxC))
-		albumLabel.SetText(fmt.Sprintf(T().FirstAlbum+": %d - %d", minA, maxA))
+		l.creationMin, l.creationMax = getCreationYearRange(l.artists)
+		l.albumMin, l.albumMax = getFirstAlbumYearRange(l.artists)
 
 		// reset des membres
-		for i := 1; i <= 8; i++ {
-			l.memberCounts[i] = true
-		}
-		for _, check := range memberChecks {
-			check.Checked = true
-			check.Refresh()
+		for _, n := range l.memberOptions {
+			l.memberCounts[n] = true
 		}
 
 		// reset des lieux
 		for _, loc := range l.allLocations {
 			l.selectedLocs[loc] = true
 		}
-		locationSearch.SetText("")
-		updateLocationChecks("")
 
-		l.rebuildGrid()
+		// reset des dates de concert
+		l.dateFrom, l.dateTo = time.Time{}, time.Time{}
+
+		// reset des favoris
+		l.favoritesOnly = false
+		l.syncFavoritesOnly()
+		l.syncFilters()
+
+		// reset du filtre de proximité
+		resetNearby()
+
+		l.filtersChanged()
 	})
 	resetBtn.Importance = widget.HighImportance
 
 	// accordion pour ranger les filtres
-	accordion := widget.NewAccordion(
+	l.accordion = widget.NewAccordion(
 		widget.NewAccordionItem(T().CreationYear, creationFilter),
 		widget.NewAccordionItem("💿 "+T().FirstAlbum, albumFilter),
-		widget.NewAccordionItem("👥 "+T().Members, memberContainer),
+		widget.NewAccordionItem("👥 "+T().Members, l.memberChips.box),
 		widget.NewAccordionItem(T().Location, locationFilter),
+		widget.NewAccordionItem("📅 "+T().ConcertDates, dateFilter),
+		widget.NewAccordionItem(T().NearMe, nearbyFilter),
+		widget.NewAccordionItem("⭐ "+T().Presets, l.createPresetFilter()),
 	)
 
 	return container.NewVBox(
-		accordion,
+		l.accordion,
 		resetBtn,
-	), locationChecks, updateLocationChecks
+	)
+}
+
+// un widget du panneau a changé : grille et texte de la requête suivent
+func (l *ArtistList) filtersChanged() {
+	l.cancelPendingQuery()
+	l.rebuildGrid()
+	if l.searchEntry == nil {
+		return
+	}
+	l.syncingQuery = true
+	l.searchEntry.SetText(l.formatQuery())
+	l.syncingQuery = false
+	l.queryErrors.Hide()
 }
 
-// rebuild grille
+// oublie la requête tapée pas encore appliquée : elle écraserait le filtre choisi ensuite
+func (l *ArtistList) cancelPendingQuery() {
+	l.queryGen++
+	if l.searchDebounce != nil {
+		l.searchDebounce.Stop()
+		l.searchDebounce = nil
+	}
+}
+
+// surligne les morceaux de requête en erreur sous la recherche
+func (l *ArtistList) showQueryErrors(input string, q *artistQuery) {
+	if len(q.errors()) == 0 {
+		l.queryErrors.Hide()
+		return
+	}
+	l.queryErrors.Segments = queryErrorSegments(input, q)
+	l.queryErrors.Refresh()
+	l.queryErrors.Show()
+}
+
+// met à jour les artistes affichés ; les cartes existantes sont réutilisées
 func (l *ArtistList) rebuildGrid() {
 	if l.grid == nil {
 		return
 	}
-	l.grid.Objects = []fyne.CanvasObject{}
-	filteredList := l.filteredArtists()
-
-	if len(filteredList) == 0 {
-		// message quand rien ne correspond
-		msgText := widget.NewLabel(T().NoResults)
-		msgText.Alignment = fyne.TextAlignCenter
-		l.grid.Add(msgText)
+	l.visible = l.filteredArtists()
+	sortArtists(l.visible, l.sortKey, l.sortDesc)
+	l.updateFilterCounts()
+	// pendant une recherche, les plus pertinents d'abord (le tri choisi départage)
+	if len(l.searchScores) > 0 {
+		sort.SliceStable(l.visible, func(i, j int) bool {
+			return l.searchScores[l.visible[i].ID] > l.searchScores[l.visible[j].ID]
+		})
+	}
+	if len(l.visible) == 0 {
+		l.empty.Show()
 	} else {
-		for _, artist := range filteredList {
-			card := createArtistCard(artist, l.onSelect)
-			l.grid.Add(card)
-		}
+		l.empty.Hide()
 	}
 	l.grid.Refresh()
 }
 
+// comptes affichés dans les filtres : artistes retenus par les autres filtres,
+// par année (histogrammes), par nombre de membres (puces) et par lieu (arbre)
+func (l *ArtistList) updateFilterCounts() {
+	if l.memberChips != nil {
+		counts := make(map[int]int)
+		for _, a := range l.filterArtists(filterMembers) {
+			counts[len(a.Members)]++
+		}
+		l.memberChips.setCounts(counts)
+	}
+	if l.locationTree != nil {
+		l.locationTree.refreshCounts()
+		l.locationTree.tree.Refresh()
+	}
+	if l.creationSlider != nil {
+		counts := make(map[int]int)
+		for _, a := range l.filterArtists(filterCreation) {
+			counts[a.CreationDate]++
+		}
+		l.creationSlider.setCounts(counts)
+	}
+	if l.albumSlider != nil {
+		counts := make(map[int]int)
+		for _, a := range l.filterArtists(filterAlbum) {
+			if year := extractAlbumYear(a.FirstAlbum); year > 0 {
+				counts[year]++
+			}
+		}
+		l.albumSlider.setCounts(counts)
+	}
+}
+
 // artistes filtrés
 func (l *ArtistList) filteredArtists() []models.Artist {
+	return l.filterArtists(filterNone)
+}
+
+// filtre qu'un passage ignore (les histogrammes montrent la densité selon les autres filtres)
+type listFilter int
+
+const (
+	filterNone listFilter = iota
+	filterCreation
+	filterAlbum
+	filterMembers
+	filterLocations
+)
+
+// artistes qui passent tous les filtres sauf skip ; les scores de recherche
+// ne sont gardés que pour le passage complet
+func (l *ArtistList) filterArtists(skip listFilter) []models.Artist {
 	res := make([]models.Artist, 0, len(l.artists))
-	st := l.searchText
+	query := search.NewQuery(l.searchText)
+	if skip == filterNone {
+		l.searchScores = make(map[int]float64)
+	}
 	seen := make(map[int]bool) // évite les doublons
+	near := l.nearbyMatches()  // nil si filtre inactif
 
 	for _, a := range l.artists {
 		// évite d'ajouter deux fois le même
@@ -376,41 +567,42 @@ func (l *ArtistList) filteredArtists() []models.Artist {
 			continue
 		}
 
-		// filtre sur le texte recherché
-		matchesSearch := st == "" || strings.Contains(strings.ToLower(a.Name), st)
-		if !matchesSearch {
-			// on cherche aussi dans les membres
-			for _, m := range a.Members {
-				if strings.Contains(strings.ToLower(m), st) {
-					matchesSearch = true
-					break
-				}
+		// filtre sur le texte recherché (accents ignorés, fautes tolérées)
+		if !query.Empty() {
+			score := query.Score(artistSearchFields(a)...)
+			if score == 0 {
+				continue
+			}
+			if skip == filterNone {
+				l.searchScores[a.ID] = score
 			}
 		}
-		if !matchesSearch {
+
+		// filtre sur les favoris
+		if l.favoritesOnly && !favorites.has(a.ID) {
 			continue
 		}
 
 		// filtre sur l'année de création
-		if a.CreationDate < l.creationMin || a.CreationDate > l.creationMax {
+		if skip != filterCreation && (a.CreationDate < l.creationMin || a.CreationDate > l.creationMax) {
 			continue
 		}
 
 		// filtre sur l'année du premier album
 		albumYear := extractAlbumYear(a.FirstAlbum)
-		if albumYear > 0 && (albumYear < l.albumMin || albumYear > l.albumMax) {
+		if skip != filterAlbum && albumYear > 0 && (albumYear < l.albumMin || albumYear > l.albumMax) {
 			continue
 		}
 
 		// filtre sur le nombre de membres
 		memberCount := len(a.Members)
-		if !l.memberCounts[memberCount] {
+		if skip != filterMembers && !l.memberCounts[memberCount] {
 			continue
 		}
 
 		// filtre sur les lieux
 		matchesLocation := false
-		if len(a.LocationsList) == 0 {
+		if skip == filterLocations || len(a.LocationsList) == 0 {
 			matchesLocation = true // pas de lieu -> on affiche
 		} else {
 			for _, loc := range a.LocationsList {
@@ -425,12 +617,61 @@ func (l *ArtistList) filteredArtists() []models.Artist {
 			continue
 		}
 
+		// filtre sur les dates de concert
+		if !l.dateFrom.IsZero() || !l.dateTo.IsZero() {
+			if l.concertsInRange(a) == 0 {
+				continue
+			}
+		}
+
+		// filtre concerts à moins de N km
+		if near != nil {
+			matchesNearby := false
+			for _, loc := range a.LocationsList {
+				if _, ok := near[loc]; ok {
+					matchesNearby = true
+					break
+				}
+			}
+			if !matchesNearby {
+				continue
+			}
+		}
+
 		seen[a.ID] = true
 		res = append(res, a)
 	}
 	return res
 }
 
+// nombre de concerts de l'artiste dans la période filtrée
+func (l *ArtistList) concertsInRange(a models.Artist) int {
+	n := 0
+	for _, t := range a.ConcertTimes {
+		if (l.dateFrom.IsZero() || !t.Before(l.dateFrom)) && (l.dateTo.IsZero() || !t.After(l.dateTo)) {
+			n++
+		}
+	}
+	return n
+}
+
+// champs où chercher le texte saisi, du plus au moins important
+func artistSearchFields(a models.Artist) []search.Field {
+	fields := []search.Field{search.NewField(a.Name, 3)}
+	for _, m := range a.Members {
+		fields = append(fields, search.NewField(m, 2))
+	}
+	for _, loc := range a.LocationsList {
+		fields = append(fields, search.NewField(loc, 1))
+	}
+	fields = append(fields,
+		search.NewField(strconv.Itoa(a.CreationDate), 1),
+		search.NewField(a.FirstAlbum, 1),
+		search.NewField(strings.Join(a.DatesList, " "), 0.8),
+	)
+	return fields
+}
+
 // utils filtres
 func getCreationYearRange(artists []models.Artist) (int, int) {
 	if len(artists) == 0 {
@@ -510,54 +751,3 @@ func formatLocationDisplay(loc string) string {
 	}
 	return strings.Join(normalized, ", ")
 }
-
-func createArtistCard(artist models.Artist, onSelect func(models.Artist)) *fyne.Container {
-	// image de l'artiste
-	uri, _ := storage.ParseURI(artist.Image)
-	img := canvas.NewImageFromURI(uri)
-	img.FillMode = canvas.ImageFillContain
-	img.SetMinSize(fyne.NewSize(200, 200))
-
-	// nom centré sous l'image (color choisi selon contraste)
-	captionBg := canvas.NewRectangle(CardBgLight)
-	nameText := canvas.NewText(artist.Name, ContrastColor(CardBgLight))
-	nameText.TextStyle = fyne.TextStyle{Bold: true}
-	nameText.Alignment = fyne.TextAlignCenter
-
-	// petit fond sous le nom
-	caption := container.NewMax(
-		captionBg,
-		container.NewPadded(container.NewCenter(nameText)),
-	)
-
-	// infos rapides (texte rendu selon contraste du fond de la carte)
-	members := canvas.NewText(fmt.Sprintf("%d "+T().Members, len(artist.Members)), ContrastColor(CardBg))
-	members.Alignment = fyne.TextAlignCenter
-
-	created := canvas.NewText(fmt.Sprintf(T().Created, artist.CreationDate), ContrastColor(CardBg))
-	created.Alignment = fyne.TextAlignCenter
-
-	// bouton pour ouvrir la fiche
-	btn := widget.NewButton(T().ShowDetails, func() {
-		onSelect(artist)
-	})
-	btn.Importance = widget.HighImportance
-
-	// container de la carte
-	card := container.NewVBox(
-		img,
-		caption,
-		members,
-		created,
-		container.NewCenter(btn),
-	)
-
-	// fond avec bordure (utilise couleurs sombres pour lisibilité)
-	bg := canvas.NewRectangle(CardBg)
-
-	return container.New(
-		layout.NewMaxLayout(),
-		bg,
-		container.NewPadded(card),
-	)
-}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"groupie-tracker/search"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// préfixes des nœuds de l'arbre des lieux ; une ville est identifiée par son
+// nom affiché ("Paris, France"), comme dans selectedLocs
+const (
+	nodeContinent = "continent:"
+	nodeCountry   = "country:"
+	nodeCity      = "city:"
+)
+
+// continent des pays non reconnus
+const otherContinent = "other"
+
+func continentLabel(key string) string {
+	switch key {
+	case "europe":
+		return T().ContinentEurope
+	case "north_america":
+		return T().ContinentNorthAmerica
+	case "south_america":
+		return T().ContinentSouthAmerica
+	case "asia":
+		return T().ContinentAsia
+	case "africa":
+		return T().ContinentAfrica
+	case "oceania":
+		return T().ContinentOceania
+	}
+	return T().ContinentOther
+}
+
+// pays d'un lieu affiché ("Paris, France") : identifiant de nœud et nom
+func locationCountryNode(location string) (id, name string) {
+	parts := strings.Split(location, ", ")
+	last := parts[len(parts)-1]
+	if code := models.CountryCode(strings.ToLower(last)); code != "" {
+		if c := models.CountryByCode(code); c != nil {
+			return nodeCountry + code, c.Name
+		}
+		return nodeCountry + code, last
+	}
+	return nodeCountry + "?" + last, strings.ReplaceAll(last, "_", " ")
+}
+
+func countryContinent(countryID string) string {
+	if c := models.Continent(strings.TrimPrefix(countryID, nodeCountry)); c != "" {
+		return c
+	}
+	return otherContinent
+}
+
+// filtre des lieux en arbre continent → pays → ville : cases à trois états,
+// nombre d'artistes par nœud, « seulement » et « inverser »
+type locationTree struct {
+	list *ArtistList
+	tree *widget.Tree
+
+	filter   string              // texte cherché dans les villes et pays
+	children map[string][]string // nœud -> enfants ("" : racine)
+	leaves   map[string][]string // nœud -> villes dessous
+	labels   map[string]string
+	counts   map[string]int // nœud -> artistes (selon les autres filtres)
+	current  string         // nœud surligné, pour « seulement »
+
+	onlyBtn *widget.Button
+}
+
+func (l *ArtistList) createLocationTree() (*locationTree, fyne.CanvasObject) {
+	t := &locationTree{list: l}
+	t.build()
+
+	t.tree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID { return t.children[id] },
+		func(id widget.TreeNodeID) bool { return !strings.HasPrefix(id, nodeCity) },
+		func(bool) fyne.CanvasObject {
+			return container.NewHBox(widget.NewCheck("", nil), widget.NewLabel(""))
+		},
+		t.updateNode,
+	)
+	t.tree.OnSelected = func(id widget.TreeNodeID) {
+		t.current = id
+		t.onlyBtn.Enable()
+	}
+	t.tree.OnUnselected = func(widget.TreeNodeID) {
+		t.current = ""
+		t.onlyBtn.Disable()
+	}
+
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder(T().Search + " " + T().Location + "...")
+	searchEntry.OnChanged = t.setFilter
+
+	t.onlyBtn = widget.NewButton(T().LocationOnly, func() { t.selectOnly(t.current) })
+	t.onlyBtn.Disable()
+	invertBtn := widget.NewButton(T().LocationInvert, t.invert)
+
+	// hauteur fixe : l'arbre défile dans l'accordéon
+	sizer := canvas.NewRectangle(nil)
+	sizer.SetMinSize(fyne.NewSize(300, 240))
+
+	return t, container.NewBorder(
+		searchEntry,
+		container.NewGridWithColumns(2, t.onlyBtn, invertBtn),
+		nil, nil,
+		container.NewStack(sizer, t.tree),
+	)
+}
+
+// reconstruit l'arbre depuis les lieux connus et le texte cherché
+func (t *locationTree) build() {
+	t.children = make(map[string][]string)
+	t.leaves = make(map[string][]string)
+	t.labels = make(map[string]string)
+	want := search.Fold(t.filter)
+
+	for _, loc := range t.list.allLocations {
+		countryID, countryName := locationCountryNode(loc)
+		if want != "" && !strings.Contains(search.Fold(loc), want) && !strings.Contains(search.Fold(countryName), want) {
+			continue
+		}
+		continentID := nodeContinent + countryContinent(countryID)
+		cityID := nodeCity + loc
+
+		if _, ok := t.labels[continentID]; !ok {
+			t.labels[continentID] = continentLabel(strings.TrimPrefix(continentID, nodeContinent))
+			t.children[""] = append(t.children[""], continentID)
+		}
+		if _, ok := t.labels[countryID]; !ok {
+			t.labels[countryID] = countryName
+			t.children[continentID] = append(t.children[continentID], countryID)
+		}
+		t.labels[cityID] = strings.Split(loc, ", ")[0]
+		t.children[countryID] = append(t.children[countryID], cityID)
+		for _, id := range []string{"", continentID, countryID} {
+			t.leaves[id] = append(t.leaves[id], loc)
+		}
+		t.leaves[cityID] = []string{loc}
+	}
+
+	// continents dans l'ordre habituel, pays et villes par nom
+	order := make(map[string]int)
+	for i, c := range models.Continents {
+		order[nodeContinent+c] = i
+	}
+	for id, kids := range t.children {
+		if id == "" {
+			sort.Slice(kids, func(i, j int) bool {
+				oi, okI := order[kids[i]]
+				oj, okJ := order[kids[j]]
+				if okI != okJ {
+					return okI
+				}
+				return oi < oj
+			})
+			continue
+		}
+		sort.Slice(kids, func(i, j int) bool { return t.labels[kids[i]] < t.labels[kids[j]] })
+	}
+}
+
+func (t *locationTree) setFilter(text string) {
+	t.filter = strings.TrimSpace(text)
+	t.build()
+	t.refreshCounts()
+	if t.tree == nil {
+		return
+	}
+	if t.filter != "" {
+		t.tree.OpenAllBranches()
+	}
+	t.tree.Refresh()
+}
+
+// nombre d'artistes par nœud, parmi ceux retenus par les autres filtres
+func (t *locationTree) refreshCounts() {
+	t.counts = make(map[string]int)
+	for _, a := range t.list.filterArtists(filterLocations) {
+		touched := make(map[string]bool)
+		for _, raw := range a.LocationsList {
+			loc := formatLocationDisplay(raw)
+			countryID, _ := locationCountryNode(loc)
+			touched[nodeCity+loc] = true
+			touched[countryID] = true
+			touched[nodeContinent+countryContinent(countryID)] = true
+		}
+		for id := range touched {
+			t.counts[id]++
+		}
+	}
+}
+
+// case cochée si toutes les villes du nœud le sont, partielle si certaines
+func (t *locationTree) updateNode(id widget.TreeNodeID, _ bool, obj fyne.CanvasObject) {
+	row := obj.(*fyne.Container)
+	check := row.Objects[0].(*widget.Check)
+	label := row.Objects[1].(*widget.Label)
+
+	selected := 0
+	for _, loc := range t.leaves[id] {
+		if t.list.selectedLocs[loc] {
+			selected++
+		}
+	}
+	check.OnChanged = nil
+	check.Checked = selected > 0 && selected == len(t.leaves[id])
+	check.Partial = selected > 0 && selected < len(t.leaves[id])
+	check.Refresh()
+	check.OnChanged = func(checked bool) { t.setNode(id, checked) }
+
+	label.SetText(fmt.Sprintf(T().LocationCountFmt, t.labels[id], t.counts[id]))
+}
+
+// coche ou décoche toutes les villes d'un nœud
+func (t *locationTree) setNode(id string, checked bool) {
+	for _, loc := range t.leaves[id] {
+		t.list.selectedLocs[loc] = checked
+	}
+	t.changed()
+}
+
+// ne garde que les villes du nœud surligné
+func (t *locationTree) selectOnly(id string) {
+	if id == "" {
+		return
+	}
+	for loc := range t.list.selectedLocs {
+		t.list.selectedLocs[loc] = false
+	}
+	t.setNode(id, true)
+}
+
+// inverse chaque ville (affichées seulement si une recherche est en cours)
+func (t *locationTree) invert() {
+	for _, loc := range t.leaves[""] {
+		t.list.selectedLocs[loc] = !t.list.selectedLocs[loc]
+	}
+	t.changed()
+}
+
+func (t *locationTree) changed() {
+	t.list.filtersChanged()
+	t.tree.Refresh()
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// taille d'une puce du filtre des membres
+var memberChipSize = fyne.NewSize(150, 36)
+
+// nombres de membres présents dans les données, croissants
+func memberCountOptions(artists []models.Artist) []int {
+	seen := make(map[int]bool)
+	var options []int
+	for _, a := range artists {
+		if n := len(a.Members); !seen[n] {
+			seen[n] = true
+			options = append(options, n)
+		}
+	}
+	sort.Ints(options)
+	return options
+}
+
+// filtre par nombre de membres : une puce par nombre présent dans les données,
+// avec le nombre d'artistes qu'elle donnerait (selon les autres filtres)
+type memberChips struct {
+	list    *ArtistList
+	box     *fyne.Container
+	buttons map[int]*widget.Button
+	counts  map[int]int
+}
+
+func (l *ArtistList) createMemberFilter() *memberChips {
+	c := &memberChips{list: l, box: container.NewGridWrap(memberChipSize)}
+	c.rebuild()
+	return c
+}
+
+// recrée les puces depuis les données ; un nombre nouveau est coché d'office
+func (c *memberChips) rebuild() {
+	l := c.list
+	l.memberOptions = memberCountOptions(l.artists)
+	c.buttons = make(map[int]*widget.Button)
+	c.box.RemoveAll()
+	for _, n := range l.memberOptions {
+		count := n
+		if _, known := l.memberCounts[count]; !known {
+			l.memberCounts[count] = true
+		}
+		btn := widget.NewButton("", func() {
+			l.memberCounts[count] = !l.memberCounts[count]
+			c.sync()
+			l.filtersChanged()
+		})
+		c.buttons[count] = btn
+		c.box.Add(btn)
+	}
+	c.sync()
+}
+
+// nombre d'artistes par nombre de membres
+func (c *memberChips) setCounts(counts map[int]int) {
+	c.counts = counts
+	c.sync()
+}
+
+// puces cochées en surbrillance, libellés avec les comptes
+func (c *memberChips) sync() {
+	for n, btn := range c.buttons {
+		btn.Text = fmt.Sprintf(T().MemberChipFmt, n, c.counts[n])
+		btn.Importance = widget.LowImportance
+		if c.list.memberCounts[n] {
+			btn.Importance = widget.HighImportance
+		}
+		btn.Refresh()
+	}
+}
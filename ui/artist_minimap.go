@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// taille de la mini carte sur la page artiste
+var miniMapSize = fyne.NewSize(600, 320)
+
+// carte de lieu enregistrée (pour le lien carte <-> liste)
+type tourCard struct {
+	obj fyne.CanvasObject
+	bg  *canvas.Rectangle
+}
+
+// mini carte de la tournée d'un artiste, liée aux cartes de lieux de la page
+type tourMiniMap struct {
+	box     *fyne.Container // "chargement..." puis la carte
+	view    *mapView
+	coords  map[string]*models.LocationCoords
+	markers map[string]*canvas.Circle
+	cards   map[string]*tourCard
+
+	pageScroll  *container.Scroll
+	pageContent fyne.CanvasObject
+	selected    string
+}
+
+func newTourMiniMap() *tourMiniMap {
+	return &tourMiniMap{
+		box:     container.NewStack(container.NewCenter(widget.NewLabel(T().Loading))),
+		coords:  make(map[string]*models.LocationCoords),
+		markers: make(map[string]*canvas.Circle),
+		cards:   make(map[string]*tourCard),
+	}
+}
+
+// section à insérer dans la page (titre + carte)
+func (t *tourMiniMap) section() fyne.CanvasObject {
+	title := canvas.NewText(T().TourMap, ContrastColor(CardBg))
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = 16
+
+	// taille fixe pour la zone carte
+	sizer := canvas.NewRectangle(BgDarker)
+	sizer.SetMinSize(miniMapSize)
+
+	return container.NewPadded(container.NewVBox(
+		title,
+		container.NewCenter(container.NewStack(sizer, t.box)),
+	))
+}
+
+// lie la page scrollable (pour amener une carte de lieu à l'écran)
+func (t *tourMiniMap) attachPage(scroll *container.Scroll, content fyne.CanvasObject) {
+	t.pageScroll = scroll
+	t.pageContent = content
+}
+
+// enregistre la carte d'un lieu
+func (t *tourMiniMap) registerCard(location string, obj fyne.CanvasObject, bg *canvas.Rectangle) {
+	t.cards[location] = &tourCard{obj: obj, bg: bg}
+}
+
+// géocode les lieux (cache d'abord) puis construit la carte
+func (t *tourMiniMap) load(locations []string) {
+	go func() {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		found := make(map[string]*models.LocationCoords)
+
+		// les requêtes passent par la file Nominatim partagée (nominatimSlot)
+		for _, loc := range locations {
+			wg.Add(1)
+			go func(loc string) {
+				defer wg.Done()
+				if c := geocodeLocationFast(loc); c != nil && (c.Latitude != 0 || c.Longitude != 0) {
+					mu.Lock()
+					found[loc] = c
+					mu.Unlock()
+				}
+			}(loc)
+		}
+		wg.Wait()
+
+		fyne.Do(func() {
+			t.build(locations, found)
+		})
+	}()
+}
+
+// construit la carte avec un marqueur par lieu géocodé
+func (t *tourMiniMap) build(locations []string, found map[string]*models.LocationCoords) {
+	if len(found) == 0 {
+		t.box.Objects = []fyne.CanvasObject{container.NewCenter(widget.NewLabel(T().NoLocations))}
+		t.box.Refresh()
+		return
+	}
+
+	var coords []*models.LocationCoords
+	for _, loc := range locations {
+		if c, ok := found[loc]; ok {
+			coords = append(coords, c)
+		}
+	}
+	t.coords = found
+	t.view = newMapView(coords, miniMapSize, 10, 40, nil)
+
+	for _, loc := range locations {
+		c, ok := found[loc]
+		if !ok {
+			continue
+		}
+		location := loc
+
+		marker := canvas.NewCircle(AccentPink)
+		marker.StrokeColor = AccentCyan
+		marker.StrokeWidth = 1
+		t.view.place(marker, c.Latitude, c.Longitude, fyne.NewSize(12, 12))
+		t.markers[location] = marker
+		t.view.markers.Add(marker)
+
+		// zone cliquable un peu plus grande que le marqueur
+		tap := newTapLayer(func(fyne.Position) {
+			t.selectLocation(location, true)
+		})
+		t.view.place(tap, c.Latitude, c.Longitude, fyne.NewSize(24, 24))
+		t.view.markers.Add(tap)
+	}
+
+	t.box.Objects = []fyne.CanvasObject{t.view.scroll}
+	t.box.Refresh()
+
+	// centre sur le premier lieu (liste triée)
+	t.view.centerOn(coords[0].Latitude, coords[0].Longitude)
+}
+
+// sélection d'un lieu : marqueur + carte de lieu mis en avant
+// fromMap : clic sur le marqueur -> on fait défiler la page jusqu'à la carte de lieu,
+// sinon (clic sur la carte de lieu) on centre la mini carte
+func (t *tourMiniMap) selectLocation(location string, fromMap bool) {
+	// on enlève l'ancienne sélection
+	if prev := t.selected; prev != "" {
+		if m, ok := t.markers[prev]; ok {
+			m.FillColor = AccentPink
+			m.Refresh()
+		}
+		if c, ok := t.cards[prev]; ok {
+			c.bg.FillColor = CardBgLight
+			c.bg.Refresh()
+		}
+	}
+	t.selected = location
+
+	if m, ok := t.markers[location]; ok {
+		m.FillColor = AccentCyan
+		m.Refresh()
+	}
+	card, hasCard := t.cards[location]
+	if hasCard {
+		card.bg.FillColor = CardBgActive
+		card.bg.Refresh()
+	}
+
+	if fromMap {
+		if hasCard && t.pageScroll != nil && t.pageContent != nil {
+			d := fyne.CurrentApp().Driver()
+			y := d.AbsolutePositionForObject(card.obj).Y - d.AbsolutePositionForObject(t.pageContent).Y
+			t.pageScroll.ScrollToOffset(fyne.NewPos(0, y-20))
+		}
+		return
+	}
+
+	if c, ok := t.coords[location]; ok && t.view != nil {
+		t.view.centerOn(c.Latitude, c.Longitude)
+	}
+}
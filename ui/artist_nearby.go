@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// panneau "près de moi" : position, activation et rayon
+func (l *ArtistList) createNearbyFilter() (fyne.CanvasObject, func()) {
+	homeLabel := widget.NewLabel(T().NoHome)
+	if l.home != nil {
+		homeLabel.SetText(fmt.Sprintf(T().HomeFmt, l.home.Name))
+	}
+
+	setHomeBtn := widget.NewButton(T().SetHome, func() {
+		showHomeLocationDialog(l.win.Window, func(h homeLocation) {
+			l.home = &h
+			homeLabel.SetText(fmt.Sprintf(T().HomeFmt, h.Name))
+			l.ensureNearbyIndex()
+			l.rebuildGrid()
+		})
+	})
+	if l.win == nil {
+		setHomeBtn.Disable()
+	}
+
+	radiusLabel := widget.NewLabel(fmt.Sprintf(T().NearbyRadiusFmt, formatDistance(l.nearbyRadius)))
+	radiusSlider := widget.NewSlider(10, 5000)
+	radiusSlider.Step = 10
+	radiusSlider.SetValue(l.nearbyRadius)
+	radiusSlider.OnChanged = func(v float64) {
+		radiusLabel.SetText(fmt.Sprintf(T().NearbyRadiusFmt, formatDistance(v)))
+	}
+	radiusSlider.OnChangeEnded = func(v float64) {
+		l.nearbyRadius = v
+		setNearbyRadius(v)
+		if l.nearbyEnabled {
+			l.rebuildGrid()
+		}
+	}
+
+	enableCheck := widget.NewCheck(T().NearbyEnable, func(checked bool) {
+		l.nearbyEnabled = checked
+		if checked {
+			l.ensureNearbyIndex()
+		}
+		l.rebuildGrid()
+	})
+	enableCheck.Checked = l.nearbyEnabled
+
+	l.nearbyStatus = widget.NewLabel("")
+	l.nearbyStatus.Hide()
+
+	reset := func() {
+		l.nearbyEnabled = false
+		enableCheck.SetChecked(false)
+	}
+
+	return container.NewVBox(
+		homeLabel,
+		setHomeBtn,
+		enableCheck,
+		radiusLabel,
+		radiusSlider,
+		l.nearbyStatus,
+	), reset
+}
+
+// géocode (cache d'abord) les lieux de concert pas encore indexés
+func (l *ArtistList) ensureNearbyIndex() {
+	if l.nearbyLoading {
+		return
+	}
+
+	var todo []string
+	for _, key := range uniqueLocationKeys(l.artists) {
+		if l.nearbyIndex.Has(key) {
+			continue
+		}
+		if c := models.GetCachedCoords(key); c != nil {
+			l.nearbyIndex.Add(key, c.Latitude, c.Longitude)
+			continue
+		}
+		todo = append(todo, key)
+	}
+	if len(todo) == 0 {
+		return
+	}
+
+	l.nearbyLoading = true
+	if l.nearbyStatus != nil {
+		l.nearbyStatus.Show()
+	}
+
+	go func() {
+		for i, key := range todo {
+			if c := geocodeLocationFast(key); c != nil && (c.Latitude != 0 || c.Longitude != 0) {
+				l.nearbyIndex.Add(key, c.Latitude, c.Longitude)
+			}
+
+			done := i + 1
+			if done%10 == 0 || done == len(todo) {
+				fyne.Do(func() {
+					if l.nearbyStatus != nil {
+						l.nearbyStatus.SetText(fmt.Sprintf(T().NearbyLoading, done, len(todo)))
+					}
+					// filtre actif : grille et comptes refiltrés ; sinon seules les distances changent
+					if l.nearbyMatches() != nil {
+						l.rebuildGrid()
+					} else if l.grid != nil {
+						l.grid.Refresh()
+					}
+				})
+			}
+		}
+
+		fyne.Do(func() {
+			l.nearbyLoading = false
+			if l.nearbyStatus != nil {
+				l.nearbyStatus.Hide()
+			}
+		})
+	}()
+}
+
+// lieux dans le rayon, nil si le filtre est inactif
+func (l *ArtistList) nearbyMatches() map[string]float64 {
+	if !l.nearbyEnabled || l.home == nil {
+		return nil
+	}
+	return l.nearbyIndex.Within(l.home.Lat, l.home.Lon, l.nearbyRadius)
+}
+
+// distance au concert le plus proche de l'artiste
+func (l *ArtistList) nearestConcertKm(a models.Artist) (float64, bool) {
+	if l.home == nil {
+		return 0, false
+	}
+	best, found := 0.0, false
+	for _, loc := range a.LocationsList {
+		if d, ok := l.nearbyIndex.DistanceKm(loc, l.home.Lat, l.home.Lon); ok && (!found || d < best) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
+
+// texte de distance pour la carte artiste ("" si inconnu)
+func (l *ArtistList) distanceText(a models.Artist) string {
+	if d, ok := l.nearestConcertKm(a); ok {
+		return fmt.Sprintf(T().DistanceFmt, formatDistance(d))
+	}
+	return ""
+}
+
+// clés brutes des lieux ("london-uk") de tous les artistes, triées
+func uniqueLocationKeys(artists []models.Artist) []string {
+	set := make(map[string]bool)
+	for _, a := range artists {
+		for _, loc := range a.LocationsList {
+			set[loc] = true
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// distance lieu <-> ma position pour la page artiste (géocode en arrière-plan si besoin)
+func newDistanceText(location string) fyne.CanvasObject {
+	home := getHomeLocation()
+	if home == nil {
+		return nil
+	}
+
+	text := canvas.NewText("", AccentCyan)
+	text.TextSize = 13
+	update := func(c *models.LocationCoords) {
+		d := models.HaversineKm(home.Lat, home.Lon, c.Latitude, c.Longitude)
+		text.Text = fmt.Sprintf(T().DistanceFmt, formatDistance(d))
+		text.Refresh()
+	}
+
+	if c := models.GetCachedCoords(location); c != nil {
+		update(c)
+	} else {
+		go func() {
+			if c := geocodeLocationFast(location); c != nil {
+				fyne.Do(func() { update(c) })
+			}
+		}()
+	}
+	return text
+}
@@ -11,7 +11,6 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
-	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -23,11 +22,19 @@ func NewArtistPage(artist models.Artist, onBack func()) fyne.CanvasObject {
 
 	// header
 	headerBg := canvas.NewRectangle(BgDarker)
+	// étoile des favoris
+	var starBtn *widget.Button
+	starBtn = newFavoriteButton(func() {
+		favorites.toggle(artist.ID)
+		setFavoriteButton(starBtn, artist.ID)
+	})
+	setFavoriteButton(starBtn, artist.ID)
+
 	header := container.NewMax(
 		headerBg,
 		container.NewVBox(
 			widget.NewLabel(""),
-			backBtn,
+			container.NewBorder(nil, nil, backBtn, starBtn),
 			widget.NewLabel(""),
 		),
 	)
@@ -35,10 +42,13 @@ func NewArtistPage(artist models.Artist, onBack func()) fyne.CanvasObject {
 
 	// contenu
 	// image
-	uri, _ := storage.ParseURI(artist.Image)
-	img := canvas.NewImageFromURI(uri)
+	img := canvas.NewImageFromResource(imagePlaceholder())
 	img.FillMode = canvas.ImageFillContain
 	img.SetMinSize(fyne.NewSize(350, 350))
+	artistImages.load(artist.Image, 0, func(res fyne.Resource) {
+		img.Resource = res
+		img.Refresh()
+	})
 
 	// titre
 	titleText := canvas.NewText(artist.Name, TextWhite)
@@ -91,8 +101,12 @@ func NewArtistPage(artist models.Artist, onBack func()) fyne.CanvasObject {
 		widget.NewLabel(""),
 	)
 
-	// on charge les concerts
-	concertContent := loadConcertContent(artist.ID)
+	// on charge les concerts (+ mini carte de la tournée)
+	miniMap := newTourMiniMap()
+	concertContent, hasConcerts := loadConcertContent(artist.ID, miniMap)
+	if hasConcerts {
+		mainContent.Add(miniMap.section())
+	}
 	if concertContent != nil {
 		mainContent.Add(concertContent)
 	}
@@ -100,6 +114,7 @@ func NewArtistPage(artist models.Artist, onBack func()) fyne.CanvasObject {
 	mainContent.Add(widget.NewLabel(""))
 
 	scroll := container.NewScroll(mainContent)
+	miniMap.attachPage(scroll, mainContent)
 
 	// mise en page finale avec border layout
 	return container.New(
@@ -109,13 +124,13 @@ func NewArtistPage(artist models.Artist, onBack func()) fyne.CanvasObject {
 	)
 }
 
-// load concerts
-func loadConcertContent(artistID int) fyne.CanvasObject {
+// load concerts (renvoie aussi si des lieux ont été trouvés)
+func loadConcertContent(artistID int, miniMap *tourMiniMap) (fyne.CanvasObject, bool) {
 	relations, err := models.FetchRelations()
 	if err != nil {
 		errorLabel := canvas.NewText(fmt.Sprintf(T().Error+": %v", err), ContrastColor(CardBg))
 		errorLabel.TextSize = 12
-		return errorLabel
+		return errorLabel, false
 	}
 
 	// on cherche les relations pour cet artiste
@@ -130,7 +145,7 @@ func loadConcertContent(artistID int) fyne.CanvasObject {
 	if datesLocations == nil || len(datesLocations) == 0 {
 		noDataLabel := canvas.NewText(T().NoConcerts, ContrastColor(CardBg))
 		noDataLabel.TextSize = 12
-		return noDataLabel
+		return noDataLabel, false
 	}
 
 	// on trie les lieux
@@ -152,15 +167,18 @@ func loadConcertContent(artistID int) fyne.CanvasObject {
 
 	for _, location := range locations {
 		dates := datesLocations[location]
-		locationItem := createLocationItem(location, dates)
+		locationItem := createLocationItem(location, dates, miniMap)
 		locationsList.Add(locationItem)
 	}
 
-	return container.NewPadded(locationsList)
+	// géocodage + construction de la mini carte en arrière-plan
+	miniMap.load(locations)
+
+	return container.NewPadded(locationsList), true
 }
 
 // carte lieu+dates
-func createLocationItem(location string, dates []string) *fyne.Container {
+func createLocationItem(location string, dates []string, miniMap *tourMiniMap) *fyne.Container {
 	// on reformate le nom du lieu
 	formattedLoc := formatLocation(location)
 
@@ -191,24 +209,36 @@ func createLocationItem(location string, dates []string) *fyne.Container {
 	mapBtn.Importance = widget.LowImportance
 
 	// carte sombre pour chaque lieu
-	cardContent := container.NewVBox(
-		locationTitle,
-		widget.NewLabel(""),
-		datesList,
-		widget.NewLabel(""),
-		mapBtn,
-	)
+	cardContent := container.NewVBox(locationTitle)
+
+	// distance depuis ma position
+	if distText := newDistanceText(location); distText != nil {
+		cardContent.Add(distText)
+	}
+
+	cardContent.Add(widget.NewLabel(""))
+	cardContent.Add(datesList)
+	cardContent.Add(widget.NewLabel(""))
+	cardContent.Add(mapBtn)
 
 	cardBg := canvas.NewRectangle(CardBgLight)
 	cardBorder := canvas.NewRectangle(AccentPink)
 	cardBorder.StrokeWidth = 2
 
-	return container.New(
+	// clic sur la carte de lieu -> centre la mini carte dessus
+	tap := newTapLayer(func(fyne.Position) {
+		miniMap.selectLocation(location, false)
+	})
+
+	card := container.New(
 		layout.NewMaxLayout(),
 		cardBorder,
 		cardBg,
+		tap,
 		container.NewPadded(cardContent),
 	)
+	miniMap.registerCard(location, card, cardBg)
+	return card
 }
 
 // format lieu
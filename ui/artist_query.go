@@ -0,0 +1,578 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"groupie-tracker/search"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// requête tapée dans la recherche de la liste :
+//
+//	members:4 created:>1990 album:1970..1980 loc:"united kingdom" -loc:london date:2019 queen
+//
+// les filtres clé:valeur remplissent le même état que le panneau de filtres,
+// le reste est cherché comme texte libre ; -loc: exclut des lieux
+
+// clés reconnues (et leurs alias français)
+var queryKeys = map[string]string{
+	"members":  "members",
+	"membres":  "members",
+	"created":  "created",
+	"creation": "created",
+	"création": "created",
+	"album":    "album",
+	"loc":      "loc",
+	"lieu":     "loc",
+	"date":     "date",
+}
+
+// un morceau de la requête, avec sa position pour surligner les erreurs
+type queryToken struct {
+	text       string
+	start, end int // en octets dans la saisie
+	err        string
+}
+
+// intervalle d'entiers ; un côté absent reste ouvert
+type intBounds struct {
+	lo, hi       int
+	hasLo, hasHi bool
+}
+
+// requête analysée ; nil : filtre absent de la requête
+type artistQuery struct {
+	text     string
+	members  []intBounds
+	created  *intBounds
+	album    *intBounds
+	locs     []string
+	notLocs  []string // -loc: lieux exclus
+	dateFrom time.Time
+	dateTo   time.Time
+	tokens   []queryToken
+}
+
+// erreurs de la requête (morceaux mal formés)
+func (q *artistQuery) errors() []queryToken {
+	var errs []queryToken
+	for _, t := range q.tokens {
+		if t.err != "" {
+			errs = append(errs, t)
+		}
+	}
+	return errs
+}
+
+// découpe la saisie en morceaux séparés par des espaces (hors guillemets)
+func splitQuery(input string) []queryToken {
+	var tokens []queryToken
+	start, inQuote := -1, false
+	for i, r := range input {
+		switch {
+		case r == '"':
+			if start < 0 {
+				start = i
+			}
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			if start >= 0 {
+				tokens = append(tokens, queryToken{text: input[start:i], start: start, end: i})
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		t := queryToken{text: input[start:], start: start, end: len(input)}
+		if inQuote {
+			t.err = T().QueryUnclosedQuote
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// analyse la saisie ; les morceaux en erreur sont ignorés pour le filtrage
+func parseArtistQuery(input string) *artistQuery {
+	q := &artistQuery{tokens: splitQuery(input)}
+	var free []string
+	for i := range q.tokens {
+		t := &q.tokens[i]
+		if t.err != "" {
+			continue
+		}
+		colon := strings.Index(t.text, ":")
+		if colon <= 0 || strings.HasPrefix(t.text, "\"") {
+			free = append(free, strings.Trim(t.text, "\""))
+			continue
+		}
+		name, negated := strings.CutPrefix(t.text[:colon], "-")
+		key, ok := queryKeys[strings.ToLower(name)]
+		if !ok {
+			t.err = fmt.Sprintf(T().QueryUnknownKeyFmt, name)
+			continue
+		}
+		if negated && key != "loc" {
+			t.err = fmt.Sprintf(T().QueryNegatedKeyFmt, key)
+			continue
+		}
+		value := strings.Trim(t.text[colon+1:], "\"")
+		if value == "" {
+			t.err = fmt.Sprintf(T().QueryMissingValueFmt, key)
+			continue
+		}
+
+		switch key {
+		case "members":
+			bounds, err := parseMemberCounts(value)
+			if err != nil {
+				t.err = err.Error()
+				continue
+			}
+			q.members = append(q.members, bounds...)
+		case "created", "album":
+			b, err := parseIntBounds(value)
+			if err != nil {
+				t.err = err.Error()
+				continue
+			}
+			if key == "created" {
+				q.created = &b
+			} else {
+				q.album = &b
+			}
+		case "loc":
+			if negated {
+				q.notLocs = append(q.notLocs, value)
+			} else {
+				q.locs = append(q.locs, value)
+			}
+		case "date":
+			from, to, err := parseDateBounds(value)
+			if err != nil {
+				t.err = err.Error()
+				continue
+			}
+			q.dateFrom, q.dateTo = from, to
+		}
+	}
+	q.text = strings.Join(free, " ")
+	return q
+}
+
+// "4", "2,3", ">3", "2..4"
+func parseMemberCounts(value string) ([]intBounds, error) {
+	var bounds []intBounds
+	for _, part := range strings.Split(value, ",") {
+		b, err := parseIntBounds(part)
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds, nil
+}
+
+func (b intBounds) contains(n int) bool {
+	return (!b.hasLo || n >= b.lo) && (!b.hasHi || n <= b.hi)
+}
+
+// "1990", ">1990", ">=1990", "<2000", "<=2000", "1970..1980", "1970..", "..1980"
+func parseIntBounds(value string) (intBounds, error) {
+	var b intBounds
+	atoi := func(s string) (int, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf(T().QueryBadNumberFmt, s)
+		}
+		return n, nil
+	}
+	var err error
+	switch {
+	case strings.Contains(value, ".."):
+		lo, hi, _ := strings.Cut(value, "..")
+		if lo != "" {
+			if b.lo, err = atoi(lo); err != nil {
+				return b, err
+			}
+			b.hasLo = true
+		}
+		if hi != "" {
+			if b.hi, err = atoi(hi); err != nil {
+				return b, err
+			}
+			b.hasHi = true
+		}
+		if b.hasLo && b.hasHi && b.lo > b.hi {
+			return b, fmt.Errorf(T().QueryBadRangeFmt, value)
+		}
+	case strings.HasPrefix(value, ">="):
+		b.lo, err = atoi(value[2:])
+		b.hasLo = true
+	case strings.HasPrefix(value, "<="):
+		b.hi, err = atoi(value[2:])
+		b.hasHi = true
+	case strings.HasPrefix(value, ">"):
+		b.lo, err = atoi(value[1:])
+		b.lo++
+		b.hasLo = true
+	case strings.HasPrefix(value, "<"):
+		b.hi, err = atoi(value[1:])
+		b.hi--
+		b.hasHi = true
+	default:
+		b.lo, err = atoi(value)
+		b.hi = b.lo
+		b.hasLo, b.hasHi = true, true
+	}
+	return b, err
+}
+
+// date de concert : année ("2019") ou jour ("14-12-2019") ; end donne la fin de la période
+func parseQueryDate(s string, end bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if year, err := strconv.Atoi(s); err == nil && len(s) == 4 {
+		if end {
+			return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC), nil
+		}
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	t, err := models.ParseConcertDate(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(T().QueryBadDateFmt, s)
+	}
+	return t, nil
+}
+
+// "2019", "14-12-2019", "2018..2019", ">2019", "<=01-06-2019" ; zéro : borne ouverte
+func parseDateBounds(value string) (from, to time.Time, err error) {
+	switch {
+	case strings.Contains(value, ".."):
+		lo, hi, _ := strings.Cut(value, "..")
+		if lo != "" {
+			if from, err = parseQueryDate(lo, false); err != nil {
+				return
+			}
+		}
+		if hi != "" {
+			if to, err = parseQueryDate(hi, true); err != nil {
+				return
+			}
+		}
+		if !from.IsZero() && !to.IsZero() && from.After(to) {
+			err = fmt.Errorf(T().QueryBadRangeFmt, value)
+		}
+	case strings.HasPrefix(value, ">="):
+		from, err = parseQueryDate(value[2:], false)
+	case strings.HasPrefix(value, "<="):
+		to, err = parseQueryDate(value[2:], true)
+	case strings.HasPrefix(value, ">"):
+		if from, err = parseQueryDate(value[1:], true); err == nil {
+			from = from.AddDate(0, 0, 1)
+		}
+	case strings.HasPrefix(value, "<"):
+		if to, err = parseQueryDate(value[1:], false); err == nil {
+			to = to.AddDate(0, 0, -1)
+		}
+	default:
+		if from, err = parseQueryDate(value, false); err == nil {
+			to, err = parseQueryDate(value, true)
+		}
+	}
+	return
+}
+
+// lieu affiché ("London, Uk") correspondant à un texte de requête :
+// dans le nom, ou nom du pays ("united kingdom")
+func locationMatchesQuery(location, value string) bool {
+	want := search.Fold(value)
+	if strings.Contains(search.Fold(location), want) {
+		return true
+	}
+	parts := strings.Split(location, ", ")
+	if c := models.CountryByCode(models.CountryCode(strings.ToLower(parts[len(parts)-1]))); c != nil {
+		return strings.Contains(search.Fold(c.Name), want)
+	}
+	return false
+}
+
+// remplit l'état des filtres depuis la requête (un filtre absent est remis à zéro)
+func (l *ArtistList) applyQuery(q *artistQuery) {
+	l.searchText = q.text
+
+	for _, n := range l.memberOptions {
+		l.memberCounts[n] = q.members == nil
+		for _, b := range q.members {
+			if b.contains(n) {
+				l.memberCounts[n] = true
+			}
+		}
+	}
+
+	l.creationMin, l.creationMax = getCreationYearRange(l.artists)
+	if b := q.created; b != nil {
+		if b.hasLo {
+			l.creationMin = b.lo
+		}
+		if b.hasHi {
+			l.creationMax = b.hi
+		}
+	}
+	l.albumMin, l.albumMax = getFirstAlbumYearRange(l.artists)
+	if b := q.album; b != nil {
+		if b.hasLo {
+			l.albumMin = b.lo
+		}
+		if b.hasHi {
+			l.albumMax = b.hi
+		}
+	}
+
+	for _, loc := range l.allLocations {
+		selected := len(q.locs) == 0
+		for _, v := range q.locs {
+			if locationMatchesQuery(loc, v) {
+				selected = true
+				break
+			}
+		}
+		for _, v := range q.notLocs {
+			if locationMatchesQuery(loc, v) {
+				selected = false
+				break
+			}
+		}
+		l.selectedLocs[loc] = selected
+	}
+	// un lieu sans correspondance est signalé comme une erreur
+	for i := range q.tokens {
+		t := &q.tokens[i]
+		colon := strings.Index(t.text, ":")
+		if t.err != "" || colon <= 0 || queryKeys[strings.ToLower(strings.TrimPrefix(t.text[:colon], "-"))] != "loc" {
+			continue
+		}
+		value := strings.Trim(t.text[colon+1:], "\"")
+		found := false
+		for _, loc := range l.allLocations {
+			if locationMatchesQuery(loc, value) {
+				found = true
+				break
+			}
+		}
+		if !found && len(l.allLocations) > 0 {
+			t.err = fmt.Sprintf(T().QueryNoLocationFmt, value)
+		}
+	}
+
+	l.dateFrom, l.dateTo = q.dateFrom, q.dateTo
+}
+
+// requête équivalente à l'état des filtres (filtres par défaut omis), suivie du texte libre
+func (l *ArtistList) formatQuery() string {
+	var terms []string
+
+	var counts []int
+	for _, n := range l.memberOptions {
+		if l.memberCounts[n] {
+			counts = append(counts, n)
+		}
+	}
+	if len(counts) < len(l.memberOptions) {
+		terms = append(terms, "members:"+formatIntList(counts))
+	}
+
+	minC, maxC := getCreationYearRange(l.artists)
+	if term := formatIntBounds(l.creationMin, l.creationMax, minC, maxC); term != "" {
+		terms = append(terms, "created:"+term)
+	}
+	minA, maxA := getFirstAlbumYearRange(l.artists)
+	if term := formatIntBounds(l.albumMin, l.albumMax, minA, maxA); term != "" {
+		terms = append(terms, "album:"+term)
+	}
+
+	// lieux cochés ou exclus, selon ce qui s'écrit le plus court
+	selected, excluded := make(map[string]bool), make(map[string]bool)
+	for _, loc := range l.allLocations {
+		if l.selectedLocs[loc] {
+			selected[loc] = true
+		} else {
+			excluded[loc] = true
+		}
+	}
+	if len(excluded) > 0 {
+		kept := locationTerms(l.allLocations, selected)
+		dropped := locationTerms(l.allLocations, excluded)
+		if len(selected) > 0 && len(kept) <= len(dropped) {
+			for _, v := range kept {
+				terms = append(terms, "loc:"+quoteQueryValue(v))
+			}
+		} else {
+			for _, v := range dropped {
+				terms = append(terms, "-loc:"+quoteQueryValue(v))
+			}
+		}
+	}
+
+	if term := formatDateBounds(l.dateFrom, l.dateTo); term != "" {
+		terms = append(terms, "date:"+term)
+	}
+
+	if l.searchText != "" {
+		terms = append(terms, l.searchText)
+	}
+	return strings.Join(terms, " ")
+}
+
+// valeurs loc: qui désignent exactement les lieux de target : un pays dont
+// tous les lieux en font partie s'écrit par son nom, les autres lieux un à un
+func locationTerms(all []string, target map[string]bool) []string {
+	type country struct {
+		name string
+		locs []string
+	}
+	countries := make(map[string]*country)
+	for _, loc := range all {
+		id, name := locationCountryNode(loc)
+		if countries[id] == nil {
+			countries[id] = &country{name: name}
+		}
+		countries[id].locs = append(countries[id].locs, loc)
+	}
+
+	var values []string
+	covered := make(map[string]bool)
+	for _, c := range countries {
+		whole := true
+		for _, loc := range c.locs {
+			if !target[loc] {
+				whole = false
+				break
+			}
+		}
+		// le nom du pays ne doit rien attraper hors de target (ex: "niger" dans "nigeria")
+		for _, loc := range all {
+			if whole && !target[loc] && locationMatchesQuery(loc, c.name) {
+				whole = false
+			}
+		}
+		if !whole || len(c.locs) < 2 {
+			continue
+		}
+		values = append(values, strings.ToLower(c.name))
+		for _, loc := range c.locs {
+			covered[loc] = true
+		}
+	}
+	for _, loc := range all {
+		if target[loc] && !covered[loc] {
+			values = append(values, loc)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// "4", "2..4" ou "1,3,5"
+func formatIntList(values []int) string {
+	if len(values) == 0 {
+		return "0"
+	}
+	contiguous := values[len(values)-1]-values[0] == len(values)-1
+	switch {
+	case len(values) == 1:
+		return strconv.Itoa(values[0])
+	case contiguous:
+		return fmt.Sprintf("%d..%d", values[0], values[len(values)-1])
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// "" si l'intervalle couvre toutes les valeurs connues
+func formatIntBounds(lo, hi, minV, maxV int) string {
+	switch {
+	case lo <= minV && hi >= maxV:
+		return ""
+	case lo == hi:
+		return strconv.Itoa(lo)
+	case lo <= minV:
+		return fmt.Sprintf("<=%d", hi)
+	case hi >= maxV:
+		return fmt.Sprintf(">=%d", lo)
+	}
+	return fmt.Sprintf("%d..%d", lo, hi)
+}
+
+// années entières quand les bornes tombent sur le 1er janvier et le 31 décembre
+func formatDateBounds(from, to time.Time) string {
+	if from.IsZero() && to.IsZero() {
+		return ""
+	}
+	format := func(t time.Time, end bool) string {
+		if (!end && t.YearDay() == 1) || (end && t.Month() == 12 && t.Day() == 31) {
+			return strconv.Itoa(t.Year())
+		}
+		return t.Format("02-01-2006")
+	}
+	switch {
+	case from.IsZero():
+		return "<=" + format(to, true)
+	case to.IsZero():
+		return ">=" + format(from, false)
+	}
+	lo, hi := format(from, false), format(to, true)
+	if lo == hi {
+		return lo
+	}
+	return lo + ".." + hi
+}
+
+func quoteQueryValue(v string) string {
+	if strings.ContainsAny(v, " \t\"") || strings.Contains(v, ",") {
+		return "\"" + strings.ReplaceAll(v, "\"", "") + "\""
+	}
+	return v
+}
+
+// requête avec ses erreurs surlignées, suivie des messages
+func queryErrorSegments(input string, q *artistQuery) []widget.RichTextSegment {
+	var segs []widget.RichTextSegment
+	plain := func(text string) {
+		segs = append(segs, &widget.TextSegment{Text: text, Style: widget.RichTextStyleInline})
+	}
+	var msgs []string
+	pos := 0
+	for _, t := range q.errors() {
+		if t.start > pos {
+			plain(input[pos:t.start])
+		}
+		segs = append(segs, &widget.TextSegment{Text: input[t.start:t.end], Style: widget.RichTextStyle{
+			ColorName: theme.ColorNameError,
+			Inline:    true,
+			TextStyle: fyne.TextStyle{Bold: true},
+		}})
+		msgs = append(msgs, t.err)
+		pos = t.end
+	}
+	plain(input[pos:])
+	segs = append(segs, &widget.TextSegment{Text: "  ⚠ " + strings.Join(msgs, " · "), Style: widget.RichTextStyle{
+		ColorName: theme.ColorNameError,
+		Inline:    true,
+	}})
+	return segs
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// préférences : critère de tri de la liste et sens
+const (
+	prefSortKey  = "list.sort_key"
+	prefSortDesc = "list.sort_desc"
+)
+
+// critère de tri de la liste d'artistes (valeur gardée dans les préférences)
+type artistSortKey string
+
+const (
+	sortByName        artistSortKey = "name"
+	sortByCreation    artistSortKey = "creation"
+	sortByFirstAlbum  artistSortKey = "first_album"
+	sortByMembers     artistSortKey = "members"
+	sortByConcerts    artistSortKey = "concerts"
+	sortByLastConcert artistSortKey = "last_concert"
+)
+
+// critères dans l'ordre du sélecteur
+var artistSortKeys = []artistSortKey{
+	sortByName, sortByCreation, sortByFirstAlbum, sortByMembers, sortByConcerts, sortByLastConcert,
+}
+
+func (k artistSortKey) label() string {
+	switch k {
+	case sortByCreation:
+		return T().SortCreation
+	case sortByFirstAlbum:
+		return T().SortFirstAlbum
+	case sortByMembers:
+		return T().SortMembers
+	case sortByConcerts:
+		return T().SortConcerts
+	case sortByLastConcert:
+		return T().SortLastConcert
+	}
+	return T().SortName
+}
+
+// valeur numérique d'un artiste pour un critère ; ok=false si inconnue
+// (pas de date lisible, pas encore de concerts chargés)
+func (k artistSortKey) value(a models.Artist) (v int64, ok bool) {
+	switch k {
+	case sortByCreation:
+		return int64(a.CreationDate), a.CreationDate > 0
+	case sortByFirstAlbum:
+		t, err := models.ParseConcertDate(a.FirstAlbum)
+		return t.Unix(), err == nil
+	case sortByMembers:
+		return int64(len(a.Members)), true
+	case sortByConcerts:
+		return int64(len(a.DatesList)), len(a.DatesList) > 0
+	case sortByLastConcert:
+		last, found := int64(0), false
+		for _, t := range a.ConcertTimes {
+			if !found || t.Unix() > last {
+				last, found = t.Unix(), true
+			}
+		}
+		return last, found
+	}
+	return 0, false
+}
+
+// trie sur place ; les valeurs inconnues restent à la fin dans les deux sens,
+// le nom départage les égalités
+func sortArtists(artists []models.Artist, key artistSortKey, desc bool) {
+	byName := func(a, b models.Artist) bool {
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}
+	sort.SliceStable(artists, func(i, j int) bool {
+		a, b := artists[i], artists[j]
+		if key == sortByName {
+			if desc {
+				return byName(b, a)
+			}
+			return byName(a, b)
+		}
+		va, okA := key.value(a)
+		vb, okB := key.value(b)
+		if okA != okB {
+			return okA
+		}
+		if va != vb {
+			if desc {
+				return va > vb
+			}
+			return va < vb
+		}
+		return byName(a, b)
+	})
+}
+
+// tri mémorisé (nom croissant par défaut)
+func getArtistSort() (artistSortKey, bool) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return sortByName, false
+	}
+	key := artistSortKey(prefs.StringWithFallback(prefSortKey, string(sortByName)))
+	for _, k := range artistSortKeys {
+		if k == key {
+			return key, prefs.Bool(prefSortDesc)
+		}
+	}
+	return sortByName, prefs.Bool(prefSortDesc)
+}
+
+func saveArtistSort(key artistSortKey, desc bool) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return
+	}
+	prefs.SetString(prefSortKey, string(key))
+	prefs.SetBool(prefSortDesc, desc)
+}
+
+// sélecteur de tri + bouton de sens
+func (l *ArtistList) createSortBar() fyne.CanvasObject {
+	labels := make([]string, len(artistSortKeys))
+	for i, k := range artistSortKeys {
+		labels[i] = k.label()
+	}
+
+	var dirBtn *widget.Button
+	updateDir := func() {
+		if l.sortDesc {
+			dirBtn.SetText("↓")
+		} else {
+			dirBtn.SetText("↑")
+		}
+	}
+	dirBtn = widget.NewButton("", func() {
+		l.sortDesc = !l.sortDesc
+		updateDir()
+		saveArtistSort(l.sortKey, l.sortDesc)
+		l.rebuildGrid()
+	})
+	updateDir()
+
+	sel := widget.NewSelect(labels, nil)
+	sel.SetSelected(l.sortKey.label())
+	sel.OnChanged = func(string) {
+		l.sortKey = artistSortKeys[sel.SelectedIndex()]
+		saveArtistSort(l.sortKey, l.sortDesc)
+		l.rebuildGrid()
+	}
+
+	return container.NewHBox(widget.NewLabel(T().SortBy), sel, dirBtn)
+}
@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/search"
+	"sort"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// nombre max de suggestions sous la recherche
+const maxArtistSuggestions = 10
+
+// champ texte qui laisse d'abord onKey traiter les touches (flèches, Échap, Entrée)
+type suggestEntry struct {
+	widget.Entry
+	onKey func(*fyne.KeyEvent) bool // true : touche consommée
+}
+
+func newSuggestEntry() *suggestEntry {
+	e := &suggestEntry{}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+func (e *suggestEntry) TypedKey(ev *fyne.KeyEvent) {
+	if e.onKey != nil && e.onKey(ev) {
+		return
+	}
+	e.Entry.TypedKey(ev)
+}
+
+// une suggestion : texte, type affiché et action au choix
+type artistSuggestion struct {
+	text  string
+	kind  string
+	apply func()
+}
+
+// suggestions typées sous la recherche de la liste
+type artistSuggest struct {
+	list    *ArtistList
+	entry   *suggestEntry
+	box     *fyne.Container // posé par-dessus la grille
+	items   *fyne.Container
+	current []artistSuggestion
+	index   int // suggestion surlignée, -1 : aucune
+}
+
+func newArtistSuggest(list *ArtistList, entry *suggestEntry) *artistSuggest {
+	s := &artistSuggest{list: list, entry: entry, index: -1}
+	entry.onKey = s.typedKey
+
+	s.items = container.NewVBox()
+	bg := canvas.NewRectangle(CardBg)
+	bg.StrokeColor = AccentCyan
+	bg.StrokeWidth = 1
+	bg.CornerRadius = 4
+	sizer := canvas.NewRectangle(CardBg)
+	sizer.SetMinSize(fyne.NewSize(360, 0))
+	s.box = container.NewStack(sizer, bg, container.NewPadded(s.items))
+	s.box.Hide()
+	return s
+}
+
+// contenu avec les suggestions en haut à gauche
+func (s *artistSuggest) over(content fyne.CanvasObject) fyne.CanvasObject {
+	return container.NewStack(content, container.NewVBox(
+		container.NewHBox(s.box, layout.NewSpacer()),
+		layout.NewSpacer(),
+	))
+}
+
+// suggestions pour le texte saisi, les plus pertinentes d'abord
+func (s *artistSuggest) matches(text string) []artistSuggestion {
+	query := search.NewQuery(text)
+	if query.Empty() {
+		return nil
+	}
+	l := s.list
+	type scored struct {
+		artistSuggestion
+		score float64
+	}
+	var found []scored
+	seen := make(map[string]bool)
+	add := func(text, kind, key string, apply func()) {
+		if seen[kind+key] {
+			return
+		}
+		score := query.Score(search.NewField(text, 1))
+		if score == 0 {
+			return
+		}
+		seen[kind+key] = true
+		found = append(found, scored{artistSuggestion{text, kind, apply}, score})
+	}
+
+	for _, a := range l.artists {
+		artist := a
+		add(a.Name, T().SuggestArtist, strconv.Itoa(a.ID), func() { l.onSelect(artist) })
+		for _, m := range a.Members {
+			add(m, T().SuggestMember, m, func() { l.onSelect(artist) })
+		}
+		for _, loc := range a.LocationsList {
+			location := formatLocationDisplay(loc)
+			add(loc, T().SuggestLocation, location, func() { s.onlyLocation(location) })
+		}
+		year := a.CreationDate
+		add(strconv.Itoa(year), T().SuggestCreation, strconv.Itoa(year), func() {
+			l.creationMin, l.creationMax = year, year
+			s.applyFilter()
+		})
+		if album := extractAlbumYear(a.FirstAlbum); album > 0 {
+			add(a.FirstAlbum, T().SuggestFirstAlbum, strconv.Itoa(album), func() {
+				l.albumMin, l.albumMax = album, album
+				s.applyFilter()
+			})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+	if len(found) > maxArtistSuggestions {
+		found = found[:maxArtistSuggestions]
+	}
+	out := make([]artistSuggestion, len(found))
+	for i, f := range found {
+		out[i] = f.artistSuggestion
+	}
+	return out
+}
+
+// ne garde que ce lieu dans le filtre des lieux
+func (s *artistSuggest) onlyLocation(location string) {
+	for loc := range s.list.selectedLocs {
+		s.list.selectedLocs[loc] = loc == location
+	}
+	s.list.selectedLocs[location] = true
+	s.applyFilter()
+}
+
+// un filtre remplace la recherche texte ; la requête réécrite le montre
+func (s *artistSuggest) applyFilter() {
+	if s.list.syncFilters != nil {
+		s.list.syncFilters()
+	}
+	s.list.searchText = ""
+	s.list.filtersChanged()
+}
+
+// met à jour la liste pendant la saisie
+func (s *artistSuggest) update(text string) {
+	s.current = s.matches(text)
+	s.index = -1
+	if len(s.current) == 0 {
+		s.hide()
+		return
+	}
+	s.render()
+	s.box.Show()
+}
+
+func (s *artistSuggest) render() {
+	s.items.RemoveAll()
+	for i, sug := range s.current {
+		pos := i
+		btn := widget.NewButton(fmt.Sprintf("%s – %s", sug.text, sug.kind), func() { s.pick(pos) })
+		btn.Alignment = widget.ButtonAlignLeading
+		btn.Importance = widget.LowImportance
+		if i == s.index {
+			btn.Importance = widget.HighImportance
+		}
+		s.items.Add(btn)
+	}
+	s.box.Refresh()
+}
+
+func (s *artistSuggest) hide() {
+	s.current = nil
+	s.index = -1
+	s.box.Hide()
+}
+
+func (s *artistSuggest) pick(i int) {
+	if i < 0 || i >= len(s.current) {
+		return
+	}
+	apply := s.current[i].apply
+	s.hide()
+	apply()
+}
+
+// ↑/↓ pour se déplacer, Entrée pour choisir, Échap pour fermer
+func (s *artistSuggest) typedKey(ev *fyne.KeyEvent) bool {
+	if !s.box.Visible() || len(s.current) == 0 {
+		return false
+	}
+	switch ev.Name {
+	case fyne.KeyDown:
+		s.index = (s.index + 1) % len(s.current)
+	case fyne.KeyUp:
+		if s.index <= 0 {
+			s.index = len(s.current)
+		}
+		s.index--
+	case fyne.KeyEscape:
+		s.hide()
+		return true
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if s.index < 0 {
+			return false
+		}
+		s.pick(s.index)
+		return true
+	default:
+		return false
+	}
+	s.render()
+	return true
+}
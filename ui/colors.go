@@ -4,15 +4,16 @@ import "image/color"
 
 // palette couleurs
 var (
-	BgDark      = color.RGBA{R: 15, G: 12, B: 41, A: 255}
-	BgDarker    = color.RGBA{R: 10, G: 8, B: 30, A: 255}
-	AccentCyan  = color.RGBA{R: 0, G: 212, B: 255, A: 255}
-	AccentPink  = color.RGBA{R: 255, G: 0, B: 110, A: 255}
-	TextWhite   = color.RGBA{R: 255, G: 255, B: 255, A: 255}
-	TextLight   = color.RGBA{R: 200, G: 200, B: 200, A: 255}
-	CardBg      = color.RGBA{R: 25, G: 22, B: 60, A: 255}
-	CardBgLight = color.RGBA{R: 35, G: 32, B: 70, A: 255}
-	TextBlack   = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+	BgDark       = color.RGBA{R: 15, G: 12, B: 41, A: 255}
+	BgDarker     = color.RGBA{R: 10, G: 8, B: 30, A: 255}
+	AccentCyan   = color.RGBA{R: 0, G: 212, B: 255, A: 255}
+	AccentPink   = color.RGBA{R: 255, G: 0, B: 110, A: 255}
+	TextWhite    = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	TextLight    = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	CardBg       = color.RGBA{R: 25, G: 22, B: 60, A: 255}
+	CardBgLight  = color.RGBA{R: 35, G: 32, B: 70, A: 255}
+	CardBgActive = color.RGBA{R: 55, G: 50, B: 110, A: 255}
+	TextBlack    = color.RGBA{R: 0, G: 0, B: 0, A: 255}
 )
 
 // ContrastColor returns black or white depending on the perceived
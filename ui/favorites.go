@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"groupie-tracker/models"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// clé des préférences pour les artistes suivis
+const prefFavorites = "favorites.ids"
+
+// artistes suivis, gardés dans les préférences ; partagés par la liste,
+// les cartes, la fiche et la page des favoris (thread UI uniquement)
+type favoriteStore struct {
+	ids       []int // ordre d'ajout
+	set       map[int]bool
+	loaded    bool
+	listeners []func()
+}
+
+var favorites = &favoriteStore{}
+
+func (f *favoriteStore) load() {
+	if f.loaded {
+		return
+	}
+	f.loaded = true
+	f.set = make(map[int]bool)
+	if prefs := appPreferences(); prefs != nil {
+		for _, id := range prefs.IntList(prefFavorites) {
+			if !f.set[id] {
+				f.set[id] = true
+				f.ids = append(f.ids, id)
+			}
+		}
+	}
+}
+
+func (f *favoriteStore) has(id int) bool {
+	f.load()
+	return f.set[id]
+}
+
+func (f *favoriteStore) list() []int {
+	f.load()
+	return append([]int(nil), f.ids...)
+}
+
+func (f *favoriteStore) toggle(id int) {
+	f.load()
+	if f.set[id] {
+		delete(f.set, id)
+		ids := f.ids[:0]
+		for _, v := range f.ids {
+			if v != id {
+				ids = append(ids, v)
+			}
+		}
+		f.ids = ids
+	} else {
+		f.set[id] = true
+		f.ids = append(f.ids, id)
+	}
+	f.save()
+}
+
+// ajoute des artistes (import), sans doublon ; renvoie le nombre de nouveaux
+func (f *favoriteStore) add(ids []int) int {
+	f.load()
+	added := 0
+	for _, id := range ids {
+		if !f.set[id] {
+			f.set[id] = true
+			f.ids = append(f.ids, id)
+			added++
+		}
+	}
+	if added > 0 {
+		f.save()
+	}
+	return added
+}
+
+func (f *favoriteStore) save() {
+	if prefs := appPreferences(); prefs != nil {
+		prefs.SetIntList(prefFavorites, f.ids)
+	}
+	for _, fn := range f.listeners {
+		fn()
+	}
+}
+
+// prévient à chaque changement (étoile, import)
+func (f *favoriteStore) onChanged(fn func()) {
+	f.listeners = append(f.listeners, fn)
+}
+
+// icônes de l'étoile : contour aux couleurs du thème, pleine en jaune
+var (
+	starOutline = theme.NewThemedResource(fyne.NewStaticResource("star_outline.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path fill="none" stroke="#000" stroke-width="2" stroke-linejoin="round" d="M12 2.5l2.9 6.1 6.6.8-4.9 4.6 1.3 6.6L12 17.3l-5.9 3.3 1.3-6.6-4.9-4.6 6.6-.8z"/></svg>`)))
+	starFilled = fyne.NewStaticResource("star_filled.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path fill="#f5c518" stroke="#f5c518" stroke-width="2" stroke-linejoin="round" d="M12 2.5l2.9 6.1 6.6.8-4.9 4.6 1.3 6.6L12 17.3l-5.9 3.3 1.3-6.6-4.9-4.6 6.6-.8z"/></svg>`))
+)
+
+// bouton étoile ; setFavoriteButton le met à jour pour un artiste
+func newFavoriteButton(onTapped func()) *widget.Button {
+	btn := widget.NewButtonWithIcon("", starOutline, onTapped)
+	btn.Importance = widget.LowImportance
+	return btn
+}
+
+func setFavoriteButton(btn *widget.Button, id int) {
+	if favorites.has(id) {
+		btn.SetIcon(starFilled)
+	} else {
+		btn.SetIcon(starOutline)
+	}
+}
+
+// fichier d'import / export : les noms aident à relire, seuls les ids comptent
+type favoritesFile struct {
+	Favorites []favoriteEntry `json:"favorites"`
+}
+
+type favoriteEntry struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func writeFavorites(w io.Writer, ids []int, artists []models.Artist) error {
+	names := make(map[int]string, len(artists))
+	for _, a := range artists {
+		names[a.ID] = a.Name
+	}
+	file := favoritesFile{Favorites: []favoriteEntry{}}
+	for _, id := range ids {
+		file.Favorites = append(file.Favorites, favoriteEntry{ID: id, Name: names[id]})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
+
+// import : un id inconnu de l'api est refusé plutôt que gardé en silence
+func readFavorites(r io.Reader, artists []models.Artist) ([]int, error) {
+	var file favoritesFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf(T().FavoritesFileInvalidFmt, err)
+	}
+	known := make(map[int]bool, len(artists))
+	for _, a := range artists {
+		known[a.ID] = true
+	}
+	ids := make([]int, 0, len(file.Favorites))
+	for _, e := range file.Favorites {
+		if !known[e.ID] {
+			return nil, fmt.Errorf(T().FavoritesFileInvalidFmt, fmt.Sprintf(T().FavoriteUnknownFmt, e.ID, e.Name))
+		}
+		ids = append(ids, e.ID)
+	}
+	return ids, nil
+}
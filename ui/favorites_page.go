@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// concerts à venir affichés par artiste
+const maxUpcomingConcerts = 5
+
+// concert daté dans un lieu
+type upcomingConcert struct {
+	date     time.Time
+	location string
+}
+
+// concerts d'aujourd'hui et après, du plus proche au plus lointain
+func upcomingConcerts(datesLocations map[string][]string, now time.Time) []upcomingConcert {
+	today := startOfDay(now)
+	var res []upcomingConcert
+	for loc, dates := range datesLocations {
+		for _, d := range dates {
+			t, err := models.ParseConcertDate(d)
+			if err != nil || t.Before(today) {
+				continue
+			}
+			res = append(res, upcomingConcert{t, loc})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].date.Equal(res[j].date) {
+			return res[i].date.Before(res[j].date)
+		}
+		return res[i].location < res[j].location
+	})
+	return res
+}
+
+// page des favoris : artistes suivis et leurs prochains concerts,
+// import / export de la liste
+func NewFavoritesPage(win *Window, artists []models.Artist, onBack func(), onArtist func(models.Artist)) {
+	win.ShowLoading(T().Loading)
+
+	go func() {
+		// sans relations on affiche quand même les favoris, sans concerts
+		relations, err := models.FetchRelations()
+		if err != nil {
+			log.Println("Erreur lors du chargement des relations:", err)
+		}
+		byArtist := make(map[int]map[string][]string)
+		if relations != nil {
+			for _, rel := range relations.Index {
+				byArtist[rel.ID] = rel.DatesLocations
+			}
+		}
+
+		fyne.Do(func() {
+			page := &favoritesPage{win: win, artists: artists, concerts: byArtist, onArtist: onArtist, loadErr: err}
+			win.SetContent(page.build(onBack))
+		})
+	}()
+}
+
+type favoritesPage struct {
+	win      *Window
+	artists  []models.Artist
+	concerts map[int]map[string][]string // id artiste -> lieu -> dates
+	onArtist func(models.Artist)
+	loadErr  error
+
+	list *fyne.Container
+}
+
+func (p *favoritesPage) build(onBack func()) fyne.CanvasObject {
+	backBtn := widget.NewButton(T().Back, onBack)
+	backBtn.Importance = widget.HighImportance
+
+	title := canvas.NewText(T().Favorites, TextWhite)
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = 24
+
+	importBtn := widget.NewButton(T().FavoritesImport, p.importFavorites)
+	exportBtn := widget.NewButton(T().FavoritesExport, p.exportFavorites)
+
+	p.list = container.NewVBox()
+	p.refresh()
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, backBtn, container.NewHBox(importBtn, exportBtn), container.NewCenter(title)),
+		widget.NewSeparator(),
+	)
+	if p.loadErr != nil {
+		warn := widget.NewLabel(fmt.Sprintf(T().Error+": %v", p.loadErr))
+		warn.Wrapping = fyne.TextWrapWord
+		header.Add(warn)
+	}
+	return container.NewBorder(header, nil, nil, nil, container.NewVScroll(p.list))
+}
+
+// une ligne par favori, par nom ; retirer l'étoile enlève la ligne
+func (p *favoritesPage) refresh() {
+	p.list.RemoveAll()
+
+	var followed []models.Artist
+	for _, a := range p.artists {
+		if favorites.has(a.ID) {
+			followed = append(followed, a)
+		}
+	}
+	sort.Slice(followed, func(i, j int) bool {
+		return strings.ToLower(followed[i].Name) < strings.ToLower(followed[j].Name)
+	})
+
+	if len(followed) == 0 {
+		empty := widget.NewLabel(T().NoFavorites)
+		empty.Alignment = fyne.TextAlignCenter
+		empty.Wrapping = fyne.TextWrapWord
+		p.list.Add(empty)
+	}
+	for _, a := range followed {
+		p.list.Add(p.favoriteRow(a))
+	}
+	p.list.Refresh()
+}
+
+func (p *favoritesPage) favoriteRow(a models.Artist) fyne.CanvasObject {
+	artist := a
+	star := newFavoriteButton(func() {
+		favorites.toggle(artist.ID)
+		p.refresh()
+	})
+	setFavoriteButton(star, artist.ID)
+
+	name := widget.NewButton(artist.Name, func() { p.onArtist(artist) })
+	name.Importance = widget.LowImportance
+	name.Alignment = widget.ButtonAlignLeading
+
+	rows := container.NewVBox(container.NewBorder(nil, nil, star, nil, name))
+	concerts := upcomingConcerts(p.concerts[artist.ID], time.Now())
+	if len(concerts) == 0 {
+		none := canvas.NewText(T().NoUpcomingConcerts, TextLight)
+		none.TextSize = 12
+		rows.Add(container.NewPadded(none))
+	}
+	for i, c := range concerts {
+		if i == maxUpcomingConcerts {
+			more := canvas.NewText(fmt.Sprintf(T().MoreDatesFmt, len(concerts)-i), TextLight)
+			more.TextSize = 12
+			rows.Add(container.NewPadded(more))
+			break
+		}
+		loc := formatLocation(c.location)
+		line := canvas.NewText(c.date.Format("02/01/2006")+"  "+getCountryFlag(loc)+" "+loc, ContrastColor(CardBg))
+		line.TextSize = 13
+		rows.Add(container.NewPadded(line))
+	}
+
+	bg := canvas.NewRectangle(CardBg)
+	bg.CornerRadius = 6
+	return container.NewPadded(container.NewStack(bg, container.NewPadded(rows)))
+}
+
+func (p *favoritesPage) importFavorites() {
+	open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, p.win.Window)
+			return
+		}
+		if r == nil {
+			return // annulé
+		}
+		defer r.Close()
+		ids, err := readFavorites(r, p.artists)
+		if err != nil {
+			dialog.ShowError(err, p.win.Window)
+			return
+		}
+		added := favorites.add(ids)
+		p.refresh()
+		dialog.ShowInformation(T().FavoritesImport, fmt.Sprintf(T().FavoritesImportedFmt, added), p.win.Window)
+	}, p.win.Window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+func (p *favoritesPage) exportFavorites() {
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, p.win.Window)
+			return
+		}
+		if w == nil {
+			return // annulé
+		}
+		err = writeFavorites(w, favorites.list(), p.artists)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			dialog.ShowError(err, p.win.Window)
+			return
+		}
+		dialog.ShowInformation(T().FavoritesExport, fmt.Sprintf(T().ExportSavedFmt, w.URI().Name()), p.win.Window)
+	}, p.win.Window)
+	save.SetFileName("groupie-tracker-favoris.json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// préréglages de filtres : choisir, enregistrer, supprimer, importer / exporter
+// en JSON, et reprise de la dernière session au démarrage
+func (l *ArtistList) createPresetFilter() fyne.CanvasObject {
+	presets := getFilterPresets()
+
+	choose := widget.NewSelect(nil, nil)
+	choose.PlaceHolder = T().PresetChoose
+	refresh := func(selected string) {
+		names := make([]string, len(presets))
+		for i, p := range presets {
+			names[i] = p.Name
+		}
+		choose.SetOptions(names)
+		choose.SetSelected(selected)
+	}
+	choose.OnChanged = func(name string) {
+		for _, p := range presets {
+			if p.Name == name {
+				l.applyFilterState(p.State)
+				return
+			}
+		}
+	}
+	refresh("")
+
+	saveBtn := widget.NewButton("💾 "+T().PresetSave, func() {
+		name := widget.NewEntry()
+		name.SetText(choose.Selected)
+		dialog.ShowForm(T().PresetSave, T().PresetSave, T().Cancel, []*widget.FormItem{
+			widget.NewFormItem(T().PresetName, name),
+		}, func(ok bool) {
+			n := strings.TrimSpace(name.Text)
+			if !ok || n == "" {
+				return
+			}
+			presets = mergeFilterPresets(presets, []filterPreset{{Name: n, State: l.filterState()}})
+			saveFilterPresets(presets)
+			// on évite de réappliquer l'état qu'on vient d'enregistrer
+			onChanged := choose.OnChanged
+			choose.OnChanged = nil
+			refresh(n)
+			choose.OnChanged = onChanged
+		}, l.win.Window)
+	})
+
+	deleteBtn := widget.NewButton(T().PresetDelete, func() {
+		name := choose.Selected
+		if name == "" {
+			return
+		}
+		dialog.ShowConfirm(T().PresetDelete, fmt.Sprintf(T().PresetDeleteFmt, name), func(ok bool) {
+			if !ok {
+				return
+			}
+			presets = removeFilterPreset(presets, name)
+			saveFilterPresets(presets)
+			refresh("")
+		}, l.win.Window)
+	})
+
+	importBtn := widget.NewButton(T().PresetImport, func() {
+		open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, l.win.Window)
+				return
+			}
+			if r == nil {
+				return // annulé
+			}
+			defer r.Close()
+			added, err := readFilterPresets(r)
+			if err != nil {
+				dialog.ShowError(err, l.win.Window)
+				return
+			}
+			presets = mergeFilterPresets(presets, added)
+			saveFilterPresets(presets)
+			refresh("")
+			dialog.ShowInformation(T().PresetImport, fmt.Sprintf(T().PresetImportedFmt, len(added)), l.win.Window)
+		}, l.win.Window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+		open.Show()
+	})
+
+	exportBtn := widget.NewButton(T().PresetExport, func() {
+		save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, l.win.Window)
+				return
+			}
+			if w == nil {
+				return // annulé
+			}
+			err = writeFilterPresets(w, presets)
+			if cerr := w.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				dialog.ShowError(err, l.win.Window)
+				return
+			}
+			dialog.ShowInformation(T().PresetExport, fmt.Sprintf(T().ExportSavedFmt, w.URI().Name()), l.win.Window)
+		}, l.win.Window)
+		save.SetFileName("groupie-tracker-filtres.json")
+		save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+		save.Show()
+	})
+
+	// les dialogues ont besoin de la fenêtre
+	if l.win == nil {
+		saveBtn.Disable()
+		deleteBtn.Disable()
+		importBtn.Disable()
+		exportBtn.Disable()
+	}
+
+	restore := widget.NewCheck(T().PresetRestoreLast, setRestoreLastFilters)
+	restore.Checked = getRestoreLastFilters()
+
+	return container.NewVBox(
+		choose,
+		container.NewGridWithColumns(2, saveBtn, deleteBtn, importBtn, exportBtn),
+		restore,
+	)
+}
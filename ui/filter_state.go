@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// clés des préférences pour les filtres enregistrés
+const (
+	prefFilterPresets = "filters.presets"
+	prefFilterLast    = "filters.last"
+	prefFilterRestore = "filters.restore_last"
+)
+
+// format des dates dans l'état des filtres
+const filterDateLayout = "2006-01-02"
+
+// état des filtres de la liste, tel qu'enregistré ou partagé en JSON.
+// Années à 0 : borne des données ; Members / Locations à null : tout coché
+type filterState struct {
+	Search      string   `json:"search,omitempty"`
+	CreationMin int      `json:"creation_min,omitempty"`
+	CreationMax int      `json:"creation_max,omitempty"`
+	AlbumMin    int      `json:"album_min,omitempty"`
+	AlbumMax    int      `json:"album_max,omitempty"`
+	Members     []int    `json:"members"`
+	Locations   []string `json:"locations"`
+	DateFrom    string   `json:"date_from,omitempty"`
+	DateTo      string   `json:"date_to,omitempty"`
+	Favorites   bool     `json:"favorites_only,omitempty"`
+}
+
+// préréglage nommé
+type filterPreset struct {
+	Name  string      `json:"name"`
+	State filterState `json:"state"`
+}
+
+// fichier d'import / export des préréglages
+type filterPresetFile struct {
+	Presets []filterPreset `json:"presets"`
+}
+
+// état courant des filtres ; ce qui ne filtre rien n'est pas écrit
+func (l *ArtistList) filterState() filterState {
+	s := filterState{Search: l.searchText}
+
+	minC, maxC := getCreationYearRange(l.artists)
+	if l.creationMin != minC {
+		s.CreationMin = l.creationMin
+	}
+	if l.creationMax != maxC {
+		s.CreationMax = l.creationMax
+	}
+	minA, maxA := getFirstAlbumYearRange(l.artists)
+	if l.albumMin != minA {
+		s.AlbumMin = l.albumMin
+	}
+	if l.albumMax != maxA {
+		s.AlbumMax = l.albumMax
+	}
+
+	members := []int{}
+	for _, n := range l.memberOptions {
+		if l.memberCounts[n] {
+			members = append(members, n)
+		}
+	}
+	if len(members) < len(l.memberOptions) {
+		s.Members = members
+	}
+
+	locations := []string{}
+	for _, loc := range l.allLocations {
+		if l.selectedLocs[loc] {
+			locations = append(locations, loc)
+		}
+	}
+	if len(locations) < len(l.allLocations) {
+		sort.Strings(locations)
+		s.Locations = locations
+	}
+
+	if !l.dateFrom.IsZero() {
+		s.DateFrom = l.dateFrom.Format(filterDateLayout)
+	}
+	if !l.dateTo.IsZero() {
+		s.DateTo = l.dateTo.Format(filterDateLayout)
+	}
+	s.Favorites = l.favoritesOnly
+	return s
+}
+
+// remplace les filtres par un état enregistré, puis met widgets et grille à jour
+func (l *ArtistList) applyFilterState(s filterState) {
+	l.cancelPendingQuery()
+	l.searchText = s.Search
+
+	minC, maxC := getCreationYearRange(l.artists)
+	l.creationMin, l.creationMax = stateBounds(s.CreationMin, s.CreationMax, minC, maxC)
+	minA, maxA := getFirstAlbumYearRange(l.artists)
+	l.albumMin, l.albumMax = stateBounds(s.AlbumMin, s.AlbumMax, minA, maxA)
+
+	members := make(map[int]bool)
+	for _, n := range s.Members {
+		members[n] = true
+	}
+	for _, n := range l.memberOptions {
+		l.memberCounts[n] = s.Members == nil || members[n]
+	}
+
+	locations := make(map[string]bool)
+	for _, loc := range s.Locations {
+		locations[loc] = true
+	}
+	for _, loc := range l.allLocations {
+		l.selectedLocs[loc] = s.Locations == nil || locations[loc]
+	}
+
+	l.dateFrom, _ = time.Parse(filterDateLayout, s.DateFrom)
+	l.dateTo, _ = time.Parse(filterDateLayout, s.DateTo)
+	l.favoritesOnly = s.Favorites
+
+	if l.memberChips != nil {
+		l.memberChips.sync()
+	}
+	if l.syncFilters != nil {
+		l.syncFilters()
+	}
+	if l.syncFavoritesOnly != nil {
+		l.syncFavoritesOnly()
+	}
+	l.filtersChanged()
+}
+
+// bornes enregistrées ramenées dans celles des données (0 : borne des données)
+func stateBounds(lo, hi, minV, maxV int) (int, int) {
+	if lo == 0 {
+		lo = minV
+	}
+	if hi == 0 {
+		hi = maxV
+	}
+	lo = max(minV, min(lo, maxV))
+	hi = max(lo, min(hi, maxV))
+	return lo, hi
+}
+
+// préréglages enregistrés, triés par nom
+func getFilterPresets() []filterPreset {
+	prefs := appPreferences()
+	if prefs == nil || prefs.String(prefFilterPresets) == "" {
+		return nil
+	}
+	var presets []filterPreset
+	if err := json.Unmarshal([]byte(prefs.String(prefFilterPresets)), &presets); err != nil {
+		log.Println("Préréglages de filtres illisibles:", err)
+		return nil
+	}
+	return presets
+}
+
+func saveFilterPresets(presets []filterPreset) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		return strings.ToLower(presets[i].Name) < strings.ToLower(presets[j].Name)
+	})
+	data, err := json.Marshal(presets)
+	if err != nil {
+		log.Println("Erreur lors de l'enregistrement des préréglages:", err)
+		return
+	}
+	prefs.SetString(prefFilterPresets, string(data))
+}
+
+// ajoute ou remplace (même nom) des préréglages
+func mergeFilterPresets(presets, added []filterPreset) []filterPreset {
+	for _, p := range added {
+		replaced := false
+		for i := range presets {
+			if presets[i].Name == p.Name {
+				presets[i] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			presets = append(presets, p)
+		}
+	}
+	return presets
+}
+
+func removeFilterPreset(presets []filterPreset, name string) []filterPreset {
+	res := presets[:0]
+	for _, p := range presets {
+		if p.Name != name {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// état des filtres à la fermeture précédente
+func getLastFilterState() (filterState, bool) {
+	prefs := appPreferences()
+	if prefs == nil || prefs.String(prefFilterLast) == "" {
+		return filterState{}, false
+	}
+	var s filterState
+	if err := json.Unmarshal([]byte(prefs.String(prefFilterLast)), &s); err != nil {
+		return filterState{}, false
+	}
+	return s, true
+}
+
+func saveLastFilterState(s filterState) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return
+	}
+	prefs.SetString(prefFilterLast, string(data))
+}
+
+// reprendre les filtres de la dernière session au démarrage
+func getRestoreLastFilters() bool {
+	if prefs := appPreferences(); prefs != nil {
+		return prefs.Bool(prefFilterRestore)
+	}
+	return false
+}
+
+func setRestoreLastFilters(restore bool) {
+	if prefs := appPreferences(); prefs != nil {
+		prefs.SetBool(prefFilterRestore, restore)
+	}
+}
+
+// export JSON lisible, à partager
+func writeFilterPresets(w io.Writer, presets []filterPreset) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(filterPresetFile{Presets: presets})
+}
+
+// import : chaque préréglage doit avoir un nom
+func readFilterPresets(r io.Reader) ([]filterPreset, error) {
+	var file filterPresetFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf(T().PresetFileInvalidFmt, err)
+	}
+	for i := range file.Presets {
+		file.Presets[i].Name = strings.TrimSpace(file.Presets[i].Name)
+		if file.Presets[i].Name == "" {
+			return nil, fmt.Errorf(T().PresetFileInvalidFmt, T().PresetNameMissing)
+		}
+	}
+	return file.Presets, nil
+}
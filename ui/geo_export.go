@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"io"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// formats d'export des données de la carte
+var geoExportFormats = []struct {
+	Label string
+	Ext   string
+}{
+	{"GeoJSON", ".geojson"},
+	{"KML (Google Earth)", ".kml"},
+	{"GPX", ".gpx"},
+}
+
+// un ConcertPlace par couple lieu/artiste, trié par lieu puis artiste
+func (d *mapData) places() []models.ConcertPlace {
+	var places []models.ConcertPlace
+	for _, loc := range d.locations {
+		for _, concert := range d.concertsByLocation[loc.Lieux] {
+			places = append(places, models.ConcertPlace{
+				Location:  loc.Lieux,
+				Latitude:  loc.Latitude,
+				Longitude: loc.Longitude,
+				Artist:    concert.Artist,
+				Dates:     concert.Dates,
+			})
+		}
+	}
+	sort.Slice(places, func(i, j int) bool {
+		if places[i].Location != places[j].Location {
+			return places[i].Location < places[j].Location
+		}
+		return places[i].Artist < places[j].Artist
+	})
+	return places
+}
+
+// écrit les données au format correspondant à l'extension
+func writeGeoExport(w io.Writer, data *mapData, ext string) error {
+	title := T().WindowTitle + " - " + T().Map
+	switch ext {
+	case ".geojson":
+		return models.WriteGeoJSON(w, data.places())
+	case ".kml":
+		return models.WriteKML(w, title, data.places())
+	case ".gpx":
+		return models.WriteGPX(w, title, data.tours())
+	}
+	return fmt.Errorf("format inconnu: %s", ext)
+}
+
+// dialogue d'export : choix du format puis du fichier
+func showGeoExportDialog(win fyne.Window, data *mapData) {
+	labels := make([]string, len(geoExportFormats))
+	for i, f := range geoExportFormats {
+		labels[i] = f.Label
+	}
+	formatSelect := widget.NewSelect(labels, nil)
+	formatSelect.SetSelectedIndex(0)
+
+	content := container.NewVBox(widget.NewLabel(T().ExportFormat), formatSelect)
+	dialog.ShowCustomConfirm(T().ExportData, T().Export, T().Cancel, content, func(ok bool) {
+		if !ok || formatSelect.SelectedIndex() < 0 {
+			return
+		}
+		ext := geoExportFormats[formatSelect.SelectedIndex()].Ext
+
+		save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if w == nil {
+				return // annulé
+			}
+
+			err = writeGeoExport(w, data, ext)
+			if cerr := w.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			dialog.ShowInformation(T().ExportData, fmt.Sprintf(T().ExportSavedFmt, w.URI().Name()), win)
+		}, win)
+		save.SetFileName("groupie-tracker-concerts" + ext)
+		save.SetFilter(storage.NewExtensionFileFilter([]string{ext}))
+		save.Show()
+	}, win)
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// clés des préférences pour la position de l'utilisateur
+const (
+	prefHomeName   = "home.name"
+	prefHomeLat    = "home.lat"
+	prefHomeLon    = "home.lon"
+	prefNearRadius = "nearby.radius"
+)
+
+// position "maison" pour la recherche de concerts proches
+type homeLocation struct {
+	Name string
+	Lat  float64
+	Lon  float64
+}
+
+// préférences de l'app (nil sans app fyne, ex: export en ligne de commande)
+func appPreferences() fyne.Preferences {
+	if a := fyne.CurrentApp(); a != nil {
+		return a.Preferences()
+	}
+	return nil
+}
+
+// position enregistrée, nil si jamais définie
+func getHomeLocation() *homeLocation {
+	prefs := appPreferences()
+	if prefs == nil || prefs.String(prefHomeName) == "" {
+		return nil
+	}
+	return &homeLocation{
+		Name: prefs.String(prefHomeName),
+		Lat:  prefs.Float(prefHomeLat),
+		Lon:  prefs.Float(prefHomeLon),
+	}
+}
+
+// enregistre la position
+func setHomeLocation(h homeLocation) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return
+	}
+	prefs.SetString(prefHomeName, h.Name)
+	prefs.SetFloat(prefHomeLat, h.Lat)
+	prefs.SetFloat(prefHomeLon, h.Lon)
+}
+
+// rayon de recherche enregistré (km)
+func getNearbyRadius() float64 {
+	if prefs := appPreferences(); prefs != nil {
+		return prefs.FloatWithFallback(prefNearRadius, 500)
+	}
+	return 500
+}
+
+func setNearbyRadius(km float64) {
+	if prefs := appPreferences(); prefs != nil {
+		prefs.SetFloat(prefNearRadius, km)
+	}
+}
+
+// "12 km" / "1 234 km"
+func formatDistance(km float64) string {
+	if km < 10 {
+		return fmt.Sprintf("%.1f km", km)
+	}
+	s := fmt.Sprintf("%d", int(km+0.5))
+	if len(s) > 3 {
+		s = s[:len(s)-3] + " " + s[len(s)-3:]
+	}
+	return s + " km"
+}
+
+// dialogue : saisie d'une ville, géocodée via Nominatim
+func showHomeLocationDialog(win fyne.Window, onSet func(homeLocation)) {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(T().HomePlaceholder)
+	if h := getHomeLocation(); h != nil {
+		entry.SetText(h.Name)
+	}
+
+	dialog.ShowForm(T().SetHome, T().Search, T().Cancel, []*widget.FormItem{
+		widget.NewFormItem(T().Location, entry),
+	}, func(ok bool) {
+		query := strings.TrimSpace(entry.Text)
+		if !ok || query == "" {
+			return
+		}
+
+		progress := dialog.NewCustomWithoutButtons(T().SetHome, widget.NewProgressBarInfinite(), win)
+		progress.Show()
+
+		go func() {
+			// texte libre : pas gardé dans le cache des lieux de concert
+			coords := nominatimSearch(query, query)
+			fyne.Do(func() {
+				progress.Hide()
+				if coords == nil || (coords.Latitude == 0 && coords.Longitude == 0) {
+					dialog.ShowError(fmt.Errorf(T().HomeNotFoundFmt, query), win)
+					return
+				}
+				h := homeLocation{Name: query, Lat: coords.Latitude, Lon: coords.Longitude}
+				setHomeLocation(h)
+				if onSet != nil {
+					onSet(h)
+				}
+			})
+		}()
+	}, win)
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+	"strconv"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// nombre de téléchargements simultanés et d'images gardées en mémoire
+const (
+	imageWorkers      = 4
+	imageMemoryLimit  = 256
+	cardThumbnailSize = 400 // px, couvre les cartes 200×200 en HiDPI
+)
+
+// délai avant de redemander une image en échec
+const imageRetryDelay = 30 * time.Second
+
+// image affichée pendant le chargement et quand il échoue
+// (imageError rend toujours la même ressource : on peut la comparer)
+var brokenImage = theme.BrokenImageIcon()
+
+func imagePlaceholder() fyne.Resource { return theme.MediaPhotoIcon() }
+func imageError() fyne.Resource       { return brokenImage }
+
+// chargement d'images en arrière-plan : pool de workers borné, cache mémoire
+// par hash d'url (le cache disque est dans models)
+type imageLoader struct {
+	mu      sync.Mutex
+	wake    *sync.Cond
+	queue   []string            // clés en attente, la dernière demandée passe d'abord
+	jobs    map[string]imageJob // clé -> demande en attente ou en cours
+	waiters map[string][]func(fyne.Resource)
+	memory  map[string]fyne.Resource
+	failed  map[string]time.Time // clé -> dernier échec, redemandée après imageRetryDelay
+	order   []string             // ordre d'entrée en mémoire, pour éviction
+	started bool
+}
+
+type imageJob struct {
+	url  string
+	size int // 0 = image d'origine
+}
+
+var artistImages = &imageLoader{
+	jobs:    make(map[string]imageJob),
+	waiters: make(map[string][]func(fyne.Resource)),
+	memory:  make(map[string]fyne.Resource),
+	failed:  make(map[string]time.Time),
+}
+
+func imageCacheKey(url string, size int) string {
+	key := models.ImageKey(url)
+	if size > 0 {
+		key += "-" + strconv.Itoa(size)
+	}
+	return key
+}
+
+// demande une image (size 0 : originale, sinon vignette) ; onLoaded est appelé sur
+// le thread UI, tout de suite si elle est en mémoire, avec imageError() en cas d'échec
+func (l *imageLoader) load(url string, size int, onLoaded func(fyne.Resource)) {
+	if url == "" {
+		onLoaded(imageError())
+		return
+	}
+	key := imageCacheKey(url, size)
+
+	l.mu.Lock()
+	if res, ok := l.memory[key]; ok {
+		l.mu.Unlock()
+		onLoaded(res)
+		return
+	}
+	if t, ok := l.failed[key]; ok && time.Since(t) < imageRetryDelay {
+		l.mu.Unlock()
+		onLoaded(imageError())
+		return
+	}
+	l.waiters[key] = append(l.waiters[key], onLoaded)
+	if _, pending := l.jobs[key]; !pending {
+		l.jobs[key] = imageJob{url: url, size: size}
+		l.queue = append(l.queue, key)
+	}
+	if !l.started {
+		l.started = true
+		l.wake = sync.NewCond(&l.mu)
+		for i := 0; i < imageWorkers; i++ {
+			go l.work()
+		}
+	}
+	l.wake.Signal()
+	l.mu.Unlock()
+}
+
+func (l *imageLoader) work() {
+	for {
+		l.mu.Lock()
+		for len(l.queue) == 0 {
+			l.wake.Wait()
+		}
+		// les cartes visibles en dernier sont servies d'abord pendant le défilement
+		key := l.queue[len(l.queue)-1]
+		l.queue = l.queue[:len(l.queue)-1]
+		job := l.jobs[key]
+		l.mu.Unlock()
+
+		var b []byte
+		var err error
+		if job.size > 0 {
+			b, err = models.FetchThumbnail(job.url, job.size)
+		} else {
+			b, err = models.FetchImage(job.url)
+		}
+
+		var res fyne.Resource
+		if err != nil {
+			fyne.LogError("Failed to load image", err)
+			res = imageError()
+		} else {
+			res = fyne.NewStaticResource(key, b)
+		}
+
+		l.mu.Lock()
+		delete(l.jobs, key)
+		waiters := l.waiters[key]
+		delete(l.waiters, key)
+		// un échec n'est pas gardé : l'image sera redemandée après imageRetryDelay
+		if err == nil {
+			delete(l.failed, key)
+			l.remember(key, res)
+		} else {
+			l.failed[key] = time.Now()
+		}
+		l.mu.Unlock()
+
+		fyne.Do(func() {
+			for _, w := range waiters {
+				w(res)
+			}
+		})
+	}
+}
+
+// garde une image en mémoire, en oubliant les plus anciennes au-delà de la limite
+func (l *imageLoader) remember(key string, res fyne.Resource) {
+	if _, ok := l.memory[key]; !ok {
+		l.order = append(l.order, key)
+	}
+	l.memory[key] = res
+	for len(l.order) > imageMemoryLimit {
+		delete(l.memory, l.order[0])
+		l.order = l.order[1:]
+	}
+}
@@ -18,24 +18,84 @@ type Translations struct {
 	WindowTitle string
 
 	// artist list
-	Artists            string
-	ShowMap            string
-	SearchPlaceholder  string
-	Filters            string
-	ResetFilters       string
-	CreationYear       string
-	FirstAlbum         string
-	Members            string
-	Location           string
-	NoResults          string
-	Min                string
-	Max                string
-	ShowDetails        string
-	DatesLabel         string
-	ViewOnMaps         string
-	LocationsListTitle string
-	NoLocations        string
-	MoreDatesFmt       string
+	Artists                 string
+	ShowMap                 string
+	SearchPlaceholder       string
+	Filters                 string
+	ResetFilters            string
+	CreationYear            string
+	FirstAlbum              string
+	Members                 string
+	Location                string
+	NoResults               string
+	ShowDetails             string
+	DatesLabel              string
+	ViewOnMaps              string
+	LocationsListTitle      string
+	NoLocations             string
+	MoreDatesFmt            string
+	LocationsCountFmt       string
+	SortBy                  string
+	SortName                string
+	SortCreation            string
+	SortFirstAlbum          string
+	SortMembers             string
+	SortConcerts            string
+	SortLastConcert         string
+	SuggestArtist           string
+	SuggestMember           string
+	SuggestLocation         string
+	SuggestCreation         string
+	SuggestFirstAlbum       string
+	QueryUnclosedQuote      string
+	QueryUnknownKeyFmt      string
+	QueryMissingValueFmt    string
+	QueryBadNumberFmt       string
+	QueryBadRangeFmt        string
+	QueryBadDateFmt         string
+	QueryNoLocationFmt      string
+	QueryNegatedKeyFmt      string
+	ConcertDates            string
+	DateFrom                string
+	DateTo                  string
+	DatePresets             string
+	PresetNext3Months       string
+	PresetThisYear          string
+	PresetLastYear          string
+	PresetAllDates          string
+	MemberChipFmt           string
+	ContinentEurope         string
+	ContinentNorthAmerica   string
+	ContinentSouthAmerica   string
+	ContinentAsia           string
+	ContinentAfrica         string
+	ContinentOceania        string
+	ContinentOther          string
+	LocationOnly            string
+	LocationInvert          string
+	LocationCountFmt        string
+	Presets                 string
+	PresetChoose            string
+	PresetSave              string
+	PresetName              string
+	PresetDelete            string
+	PresetDeleteFmt         string
+	PresetImport            string
+	PresetExport            string
+	PresetImportedFmt       string
+	PresetFileInvalidFmt    string
+	PresetNameMissing       string
+	PresetRestoreLast       string
+	Favorites               string
+	FavoritesOnly           string
+	NoFavorites             string
+	NoUpcomingConcerts      string
+	FavoritesImport         string
+	FavoritesExport         string
+	FavoritesImportedFmt    string
+	FavoritesFileInvalidFmt string
+	FavoriteUnknownFmt      string
+	ConcertsInRangeFmt      string
 
 	// artist page
 	Created         string
@@ -43,11 +103,62 @@ type Translations struct {
 	GroupMembers    string
 	Concerts        string
 	NoConcerts      string
+	TourMap         string
 
 	// map page
-	Map              string
-	ConcertLocations string
-	SelectLocation   string
+	Map                string
+	ConcertLocations   string
+	SelectLocation     string
+	ExportImage        string
+	ExportResolution   string
+	Export             string
+	Cancel             string
+	ExportSavedFmt     string
+	LegendToursFmt     string
+	ExportData         string
+	ExportFormat       string
+	FilterLocations    string
+	MarkerSummaryFmt   string
+	VectorBasemap      string
+	DarkTiles          string
+	SearchPlace        string
+	SearchPlaceFmt     string
+	RecentJumps        string
+	NoRecentJumps      string
+	OutsideMapFmt      string
+	ChoroplethOff      string
+	ChoroplethConcerts string
+	ChoroplethArtists  string
+	CountryConcertsFmt string
+	CountryArtistsFmt  string
+	GeocodedFmt        string
+	FailedPlacesFmt    string
+	Retry              string
+	RetryAll           string
+	MapLoadFailed      string
+	UseOfflineData     string
+	OfflineDataFmt     string
+	OfflineArtistsData string
+	MapStageErrorFmt   string
+	StageMap           string
+	StageList          string
+	StageLayers        string
+	StageMarkers       string
+	StageLayout        string
+
+	// concerts près de moi
+	NearMe          string
+	NearbyEnable    string
+	NearbyRadiusFmt string
+	SetHome         string
+	PickHomeOnMap   string
+	HomePlaceholder string
+	HomeFmt         string
+	NoHome          string
+	HomeNotFoundFmt string
+	HomeFromMapFmt  string
+	NearbyLoading   string
+	DistanceFmt     string
 }
 
 var Fr = Translations{
@@ -58,34 +169,144 @@ var Fr = Translations{
 
 	WindowTitle: "Groupie Tracker",
 
-	Artists:            "Artistes",
-	ShowMap:            "🗺️ Voir la Carte",
-	SearchPlaceholder:  "Rechercher un artiste, membre, album...",
-	Filters:            "Filtres",
-	ResetFilters:       "Réinitialiser",
-	CreationYear:       "Année de création",
-	FirstAlbum:         "Premier album",
-	Members:            "Membres",
-	Location:           "Lieu",
-	NoResults:          "Aucun artiste trouvé",
-	Min:                "Min",
-	Max:                "Max",
-	ShowDetails:        "Voir les détails",
-	DatesLabel:         "Dates de concert:",
-	ViewOnMaps:         "Voir sur Maps",
-	LocationsListTitle: "Liste des lieux de concerts",
-	NoLocations:        "Aucun lieu de concert",
-	MoreDatesFmt:       "... et %d autres dates",
+	Artists:                 "Artistes",
+	ShowMap:                 "🗺️ Voir la Carte",
+	SearchPlaceholder:       "Rechercher un artiste, membre, album...",
+	Filters:                 "Filtres",
+	ResetFilters:            "Réinitialiser",
+	CreationYear:            "Année de création",
+	FirstAlbum:              "Premier album",
+	Members:                 "Membres",
+	Location:                "Lieu",
+	NoResults:               "Aucun artiste trouvé",
+	ShowDetails:             "Voir les détails",
+	DatesLabel:              "Dates de concert:",
+	ViewOnMaps:              "Voir sur Maps",
+	LocationsListTitle:      "Liste des lieux de concerts",
+	NoLocations:             "Aucun lieu de concert",
+	MoreDatesFmt:            "... et %d autres dates",
+	LocationsCountFmt:       "%d lieux",
+	SortBy:                  "Trier par",
+	SortName:                "Nom",
+	SortCreation:            "Année de création",
+	SortFirstAlbum:          "Premier album",
+	SortMembers:             "Nombre de membres",
+	SortConcerts:            "Nombre de concerts",
+	SortLastConcert:         "Concert le plus récent",
+	SuggestArtist:           "artiste/groupe",
+	SuggestMember:           "membre",
+	SuggestLocation:         "lieu",
+	SuggestCreation:         "date de création",
+	SuggestFirstAlbum:       "premier album",
+	QueryUnclosedQuote:      "guillemet non fermé",
+	QueryUnknownKeyFmt:      "filtre inconnu « %s » (members, created, album, loc, date)",
+	QueryMissingValueFmt:    "valeur manquante après %s:",
+	QueryBadNumberFmt:       "nombre attendu, pas « %s »",
+	QueryBadRangeFmt:        "intervalle inversé « %s »",
+	QueryBadDateFmt:         "date attendue (2019 ou 14-12-2019), pas « %s »",
+	QueryNoLocationFmt:      "aucun lieu ne correspond à « %s »",
+	QueryNegatedKeyFmt:      "seul loc accepte « - » (pas %s)",
+	ConcertDates:            "Dates de concert",
+	DateFrom:                "Du",
+	DateTo:                  "Au",
+	DatePresets:             "Période...",
+	PresetNext3Months:       "3 prochains mois",
+	PresetThisYear:          "Cette année",
+	PresetLastYear:          "L'année dernière",
+	PresetAllDates:          "Toutes les dates",
+	MemberChipFmt:           "%d membre(s) · %d",
+	ContinentEurope:         "Europe",
+	ContinentNorthAmerica:   "Amérique du Nord",
+	ContinentSouthAmerica:   "Amérique du Sud",
+	ContinentAsia:           "Asie",
+	ContinentAfrica:         "Afrique",
+	ContinentOceania:        "Océanie",
+	ContinentOther:          "Autres",
+	LocationOnly:            "Seulement la sélection",
+	LocationInvert:          "Inverser",
+	LocationCountFmt:        "%s · %d",
+	Presets:                 "Filtres enregistrés",
+	PresetChoose:            "Choisir un préréglage...",
+	PresetSave:              "Enregistrer",
+	PresetName:              "Nom",
+	PresetDelete:            "Supprimer",
+	PresetDeleteFmt:         "Supprimer le préréglage « %s » ?",
+	PresetImport:            "Importer",
+	PresetExport:            "Exporter",
+	PresetImportedFmt:       "%d préréglage(s) importé(s)",
+	PresetFileInvalidFmt:    "fichier de préréglages invalide : %v",
+	PresetNameMissing:       "préréglage sans nom",
+	PresetRestoreLast:       "Reprendre les filtres de la dernière session",
+	Favorites:               "Favoris",
+	FavoritesOnly:           "Favoris seulement",
+	NoFavorites:             "Aucun favori : touchez l'étoile d'un artiste pour le suivre",
+	NoUpcomingConcerts:      "Aucun concert à venir",
+	FavoritesImport:         "Importer",
+	FavoritesExport:         "Exporter",
+	FavoritesImportedFmt:    "%d favori(s) ajouté(s)",
+	FavoritesFileInvalidFmt: "fichier de favoris invalide : %v",
+	FavoriteUnknownFmt:      "artiste inconnu (id %d, %q)",
+	ConcertsInRangeFmt:      "%d concert(s) dans la période",
 
 	Created:         "Créé en %d",
 	FirstAlbumLabel: "💿 Premier album: %s",
 	GroupMembers:    "👥 Membres du groupe",
 	Concerts:        "🎤 Concerts",
 	NoConcerts:      "Aucune information de concert disponible",
+	TourMap:         "🗺️ Carte de la tournée",
+
+	Map:                "Carte",
+	ConcertLocations:   "🗺️ Lieux de Concerts",
+	SelectLocation:     "Sélectionnez un lieu pour voir les détails",
+	ExportImage:        "🖼️ Exporter l'image",
+	ExportResolution:   "Résolution",
+	Export:             "Exporter",
+	Cancel:             "Annuler",
+	ExportSavedFmt:     "Fichier enregistré : %s",
+	LegendToursFmt:     "Tournées (%d artistes)",
+	ExportData:         "📤 Exporter les données",
+	ExportFormat:       "Format",
+	FilterLocations:    "Filtrer par lieu ou artiste...",
+	MarkerSummaryFmt:   "%d artistes · %d dates",
+	VectorBasemap:      "Fond vectoriel (hors ligne)",
+	DarkTiles:          "Tuiles sombres",
+	SearchPlace:        "Aller à un lieu...",
+	SearchPlaceFmt:     "🔍 Chercher « %s »",
+	RecentJumps:        "Lieux récents",
+	NoRecentJumps:      "Aucun lieu récent",
+	OutsideMapFmt:      "« %s » est hors de la carte",
+	ChoroplethOff:      "Carte : lieux",
+	ChoroplethConcerts: "Concerts par pays",
+	ChoroplethArtists:  "Artistes par pays",
+	CountryConcertsFmt: "%s : %d concerts",
+	CountryArtistsFmt:  "%s : %d artistes",
+	GeocodedFmt:        "%d/%d lieux géocodés",
+	FailedPlacesFmt:    "⚠ %d lieux introuvables",
+	Retry:              "↻ Réessayer",
+	RetryAll:           "↻ Tout réessayer",
+	MapLoadFailed:      "Impossible de charger la carte",
+	UseOfflineData:     "📦 Utiliser les données locales",
+	OfflineDataFmt:     "⚠ Données hors ligne du %s",
+	OfflineArtistsData: "⚠ Hors ligne : lieux des artistes, sans dates",
+	MapStageErrorFmt:   "Échec à l'étape « %s » : %v",
+	StageMap:           "carte",
+	StageList:          "liste des lieux",
+	StageLayers:        "calques",
+	StageMarkers:       "marqueurs",
+	StageLayout:        "mise en page",
 
-	Map:              "Carte",
-	ConcertLocations: "🗺️ Lieux de Concerts",
-	SelectLocation:   "Sélectionnez un lieu pour voir les détails",
+	NearMe:          "📍 Près de moi",
+	NearbyEnable:    "Seulement les concerts proches",
+	NearbyRadiusFmt: "Rayon : %s",
+	SetHome:         "Définir ma position",
+	PickHomeOnMap:   "📍 Choisir ma position sur la carte",
+	HomePlaceholder: "Ville, pays (ex: Paris, France)",
+	HomeFmt:         "Ma position : %s",
+	NoHome:          "Aucune position définie",
+	HomeNotFoundFmt: "Lieu introuvable : %s",
+	HomeFromMapFmt:  "Définir %s comme ma position ?",
+	NearbyLoading:   "Géocodage des lieux : %d/%d",
+	DistanceFmt:     "📍 à %s",
 }
 
 var En = Translations{
@@ -96,34 +317,144 @@ var En = Translations{
 
 	WindowTitle: "Groupie Tracker",
 
-	Artists:            "Artists",
-	ShowMap:            "🗺️ Show Map",
-	SearchPlaceholder:  "Search artist, member, album...",
-	Filters:            "Filters",
-	ResetFilters:       "Reset",
-	CreationYear:       "Creation Year",
-	FirstAlbum:         "First Album",
-	Members:            "Members",
-	Location:           "Location",
-	NoResults:          "No artists found",
-	Min:                "Min",
-	Max:                "Max",
-	ShowDetails:        "Show details",
-	DatesLabel:         "Concert dates:",
-	ViewOnMaps:         "View on Maps",
-	LocationsListTitle: "List of concert locations",
-	NoLocations:        "No concert locations",
-	MoreDatesFmt:       "... and %d more dates",
+	Artists:                 "Artists",
+	ShowMap:                 "🗺️ Show Map",
+	SearchPlaceholder:       "Search artist, member, album...",
+	Filters:                 "Filters",
+	ResetFilters:            "Reset",
+	CreationYear:            "Creation Year",
+	FirstAlbum:              "First Album",
+	Members:                 "Members",
+	Location:                "Location",
+	NoResults:               "No artists found",
+	ShowDetails:             "Show details",
+	DatesLabel:              "Concert dates:",
+	ViewOnMaps:              "View on Maps",
+	LocationsListTitle:      "List of concert locations",
+	NoLocations:             "No concert locations",
+	MoreDatesFmt:            "... and %d more dates",
+	LocationsCountFmt:       "%d locations",
+	SortBy:                  "Sort by",
+	SortName:                "Name",
+	SortCreation:            "Creation year",
+	SortFirstAlbum:          "First album",
+	SortMembers:             "Member count",
+	SortConcerts:            "Number of concerts",
+	SortLastConcert:         "Most recent concert",
+	SuggestArtist:           "artist/band",
+	SuggestMember:           "member",
+	SuggestLocation:         "location",
+	SuggestCreation:         "creation date",
+	SuggestFirstAlbum:       "first album",
+	QueryUnclosedQuote:      "unclosed quote",
+	QueryUnknownKeyFmt:      "unknown filter \"%s\" (members, created, album, loc, date)",
+	QueryMissingValueFmt:    "missing value after %s:",
+	QueryBadNumberFmt:       "expected a number, not \"%s\"",
+	QueryBadRangeFmt:        "reversed range \"%s\"",
+	QueryBadDateFmt:         "expected a date (2019 or 14-12-2019), not \"%s\"",
+	QueryNoLocationFmt:      "no location matches \"%s\"",
+	QueryNegatedKeyFmt:      "only loc can be negated with \"-\" (not %s)",
+	ConcertDates:            "Concert dates",
+	DateFrom:                "From",
+	DateTo:                  "To",
+	DatePresets:             "Period...",
+	PresetNext3Months:       "Next 3 months",
+	PresetThisYear:          "This year",
+	PresetLastYear:          "Last year",
+	PresetAllDates:          "All dates",
+	MemberChipFmt:           "%d member(s) · %d",
+	ContinentEurope:         "Europe",
+	ContinentNorthAmerica:   "North America",
+	ContinentSouthAmerica:   "South America",
+	ContinentAsia:           "Asia",
+	ContinentAfrica:         "Africa",
+	ContinentOceania:        "Oceania",
+	ContinentOther:          "Other",
+	LocationOnly:            "Select only",
+	LocationInvert:          "Invert",
+	LocationCountFmt:        "%s · %d",
+	Presets:                 "Saved filters",
+	PresetChoose:            "Choose a preset...",
+	PresetSave:              "Save",
+	PresetName:              "Name",
+	PresetDelete:            "Delete",
+	PresetDeleteFmt:         "Delete preset \"%s\"?",
+	PresetImport:            "Import",
+	PresetExport:            "Export",
+	PresetImportedFmt:       "%d preset(s) imported",
+	PresetFileInvalidFmt:    "invalid presets file: %v",
+	PresetNameMissing:       "preset without a name",
+	PresetRestoreLast:       "Restore last session filters",
+	Favorites:               "Favorites",
+	FavoritesOnly:           "Favorites only",
+	NoFavorites:             "No favorites yet: tap an artist's star to follow them",
+	NoUpcomingConcerts:      "No upcoming concerts",
+	FavoritesImport:         "Import",
+	FavoritesExport:         "Export",
+	FavoritesImportedFmt:    "%d favorite(s) added",
+	FavoritesFileInvalidFmt: "invalid favorites file: %v",
+	FavoriteUnknownFmt:      "unknown artist (id %d, %q)",
+	ConcertsInRangeFmt:      "%d concert(s) in range",
 
 	Created:         "Created %d",
 	FirstAlbumLabel: "💿 First album: %s",
 	GroupMembers:    "👥 Group Members",
 	Concerts:        "🎤 Concerts",
 	NoConcerts:      "No concert information available",
+	TourMap:         "🗺️ Tour map",
+
+	Map:                "Map",
+	ConcertLocations:   "🗺️ Concert Locations",
+	SelectLocation:     "Select a location to see details",
+	ExportImage:        "🖼️ Export image",
+	ExportResolution:   "Resolution",
+	Export:             "Export",
+	Cancel:             "Cancel",
+	ExportSavedFmt:     "File saved: %s",
+	LegendToursFmt:     "Tours (%d artists)",
+	ExportData:         "📤 Export data",
+	ExportFormat:       "Format",
+	FilterLocations:    "Filter by place or artist...",
+	MarkerSummaryFmt:   "%d artists · %d dates",
+	VectorBasemap:      "Vector basemap (offline)",
+	DarkTiles:          "Dark tiles",
+	SearchPlace:        "Go to a place...",
+	SearchPlaceFmt:     "🔍 Search \"%s\"",
+	RecentJumps:        "Recent places",
+	NoRecentJumps:      "No recent places",
+	OutsideMapFmt:      "\"%s\" is outside the map",
+	ChoroplethOff:      "Map: places",
+	ChoroplethConcerts: "Concerts per country",
+	ChoroplethArtists:  "Artists per country",
+	CountryConcertsFmt: "%s: %d concerts",
+	CountryArtistsFmt:  "%s: %d artists",
+	GeocodedFmt:        "%d/%d locations geocoded",
+	FailedPlacesFmt:    "⚠ %d places not found",
+	Retry:              "↻ Retry",
+	RetryAll:           "↻ Retry all",
+	MapLoadFailed:      "Could not load the map",
+	UseOfflineData:     "📦 Use local data",
+	OfflineDataFmt:     "⚠ Offline data from %s",
+	OfflineArtistsData: "⚠ Offline: artist places, without dates",
+	MapStageErrorFmt:   "Failed at stage \"%s\": %v",
+	StageMap:           "map",
+	StageList:          "locations list",
+	StageLayers:        "layers",
+	StageMarkers:       "markers",
+	StageLayout:        "layout",
 
-	Map:              "Map",
-	ConcertLocations: "🗺️ Concert Locations",
-	SelectLocation:   "Select a location to see details",
+	NearMe:          "📍 Near me",
+	NearbyEnable:    "Only concerts near me",
+	NearbyRadiusFmt: "Radius: %s",
+	SetHome:         "Set my location",
+	PickHomeOnMap:   "📍 Pick my location on the map",
+	HomePlaceholder: "City, country (e.g. Paris, France)",
+	HomeFmt:         "My location: %s",
+	NoHome:          "No location set",
+	HomeNotFoundFmt: "Place not found: %s",
+	HomeFromMapFmt:  "Set %s as my location?",
+	NearbyLoading:   "Geocoding places: %d/%d",
+	DistanceFmt:     "📍 %s away",
 }
 
 // init translations cache (call once at startup)
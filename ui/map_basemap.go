@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// préférence : fond vectoriel seul (pas de tuiles OSM)
+const prefVectorBasemap = "map.vector_only"
+
+// palette du fond vectoriel (thème sombre de l'app)
+var (
+	basemapOcean  = BgDarker
+	basemapLand   = CardBg
+	basemapBorder = color.NRGBA{R: 0, G: 212, B: 255, A: 60}
+)
+
+// nombre max de pixels du fond rendu pour une vue (l'image est ensuite étirée)
+const basemapMaxPixels = 2_000_000
+
+// fond vectoriel seul, enregistré dans les préférences
+func useVectorBasemap() bool {
+	prefs := appPreferences()
+	return prefs != nil && prefs.Bool(prefVectorBasemap)
+}
+
+func setVectorBasemap(on bool) {
+	if prefs := appPreferences(); prefs != nil {
+		prefs.SetBool(prefVectorBasemap, on)
+	}
+}
+
+// dessine océan + pays ; project : lat/lon -> pixel dans dst,
+// worldWidth : largeur d'un tour du monde en pixels (pour répéter la carte)
+func drawBasemap(dst *image.RGBA, project func(lat, lon float64) (float64, float64), worldWidth float64, scale float64) {
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(basemapOcean), image.Point{}, draw.Src)
+	drawCountries(dst, project, worldWidth, scale, func(*models.CountryShape) color.Color {
+		return basemapLand
+	}, basemapBorder)
+}
+
+// remplit chaque pays avec fill (nil : pays ignoré) et trace sa frontière
+func drawCountries(dst *image.RGBA, project func(lat, lon float64) (float64, float64), worldWidth float64, scale float64,
+	fill func(c *models.CountryShape) color.Color, border color.Color) {
+	bounds := dst.Bounds()
+	countries := models.WorldCountries()
+	for ci := range countries {
+		col := fill(&countries[ci])
+		if col == nil {
+			continue
+		}
+		for _, ring := range countries[ci].Rings {
+			pts := make([][2]float64, len(ring))
+			minX, maxX := math.Inf(1), math.Inf(-1)
+			for i, p := range ring {
+				x, y := project(math.Max(-85, math.Min(85, p[1])), p[0])
+				pts[i] = [2]float64{x, y}
+				minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			}
+
+			// copies décalées d'un tour du monde si la vue déborde
+			for _, off := range []float64{-worldWidth, 0, worldWidth} {
+				if maxX+off < float64(bounds.Min.X) || minX+off > float64(bounds.Max.X) {
+					continue
+				}
+				shifted := pts
+				if off != 0 {
+					shifted = make([][2]float64, len(pts))
+					for i, p := range pts {
+						shifted[i] = [2]float64{p[0] + off, p[1]}
+					}
+				}
+				fillPolygon(dst, shifted, col)
+				for i := range shifted {
+					a, b := shifted[i], shifted[(i+1)%len(shifted)]
+					strokeLine(dst, a[0], a[1], b[0], b[1], scale, border)
+				}
+			}
+		}
+	}
+}
+
+// fond vectoriel de la grille de tuiles d'une vue
+func renderViewBasemap(m *mapView) image.Image {
+	return renderViewLayer(m, drawBasemap)
+}
+
+// image de la taille de la grille de tuiles d'une vue (réduite si la grille est grande),
+// peinte par paint avec la projection de la vue
+func renderViewLayer(m *mapView, paint func(dst *image.RGBA, project func(lat, lon float64) (float64, float64), worldWidth, scale float64)) *image.RGBA {
+	w, h := float64(m.size.Width), float64(m.size.Height)
+	s := math.Min(1, math.Sqrt(basemapMaxPixels/(w*h)))
+
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(w*s)), int(math.Ceil(h*s))))
+	project := func(lat, lon float64) (float64, float64) {
+		tx, ty := latLonToTileXY(lat, lon, m.zoom)
+		return (tx - float64(m.xMin)) * tileSize * s, (ty - float64(m.yMin)) * tileSize * s
+	}
+	paint(img, project, float64(int(1)<<m.zoom)*tileSize*s, math.Max(1, s))
+	return img
+}
@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"image"
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ce que la carte colorie par pays
+type choroplethMode int
+
+const (
+	choroplethOff      choroplethMode = iota // points seuls
+	choroplethConcerts                       // nombre de concerts (dates)
+	choroplethArtists                        // nombre d'artistes distincts
+)
+
+// nombre de classes de couleur de la légende
+const choroplethClasses = 5
+
+// frontières des pays coloriés
+var choroplethBorder = color.NRGBA{R: 255, G: 255, B: 255, A: 90}
+
+// pays d'un lieu : code tiré de la clé ("paris-france"), sinon pays contenant le point
+func locationCountry(loc *models.LocationCoords) string {
+	if code := models.CountryCode(loc.Lieux); code != "" {
+		return code
+	}
+	if c := models.CountryAt(loc.Latitude, loc.Longitude); c != nil {
+		return c.Code
+	}
+	return ""
+}
+
+// comptes par code pays (concerts ou artistes distincts)
+func countryCounts(data *mapData, mode choroplethMode) map[string]int {
+	counts := make(map[string]int)
+	artists := make(map[string]map[int]bool)
+	for _, loc := range data.locations {
+		code := locationCountry(loc)
+		if code == "" {
+			continue
+		}
+		for _, c := range data.concertsByLocation[loc.Lieux] {
+			if mode == choroplethConcerts {
+				counts[code] += len(c.Dates)
+				continue
+			}
+			if artists[code] == nil {
+				artists[code] = make(map[int]bool)
+			}
+			artists[code][c.ArtistID] = true
+		}
+	}
+	for code, ids := range artists {
+		counts[code] = len(ids)
+	}
+	return counts
+}
+
+// bornes hautes des classes (échelle racine carrée : les petits pays restent lisibles)
+func choroplethBreaks(maxCount int) []int {
+	var breaks []int
+	for i := 1; i <= choroplethClasses; i++ {
+		b := int(math.Ceil(float64(maxCount) * math.Pow(float64(i)/choroplethClasses, 2)))
+		if len(breaks) == 0 || b > breaks[len(breaks)-1] {
+			breaks = append(breaks, b)
+		}
+	}
+	return breaks
+}
+
+// classe d'un compte (0 = plus faible)
+func choroplethClass(count int, breaks []int) int {
+	for i, b := range breaks {
+		if count <= b {
+			return i
+		}
+	}
+	return len(breaks) - 1
+}
+
+// couleur d'une classe : du violet des cartes au rose d'accent
+func choroplethColor(class, classes int) color.NRGBA {
+	t := 1.0
+	if classes > 1 {
+		t = float64(class) / float64(classes-1)
+	}
+	mix := func(a, b uint8) uint8 { return uint8(float64(a) + (float64(b)-float64(a))*t) }
+	return color.NRGBA{
+		R: mix(CardBgActive.R, AccentPink.R),
+		G: mix(CardBgActive.G, AccentPink.G),
+		B: mix(CardBgActive.B, AccentPink.B),
+		A: 210,
+	}
+}
+
+// calque choroplèthe d'une vue : pays coloriés, légende et compte au survol
+type choroplethLayer struct {
+	view   *mapView
+	data   *mapData
+	mode   choroplethMode
+	counts map[string]int
+	breaks []int
+
+	image  *canvas.Image
+	hover  *hoverLayer
+	tip    *fyne.Container
+	tipTxt *canvas.Text
+	legend *fyne.Container // posé par-dessus la carte, hors du scroll
+	rows   *fyne.Container
+	title  *canvas.Text
+	gen    int // ignore les rendus d'un mode précédent
+}
+
+func newChoroplethLayer(view *mapView, data *mapData) *choroplethLayer {
+	l := &choroplethLayer{view: view, data: data}
+
+	l.hover = newHoverLayer(l.showCount, l.hideCount)
+	l.hover.Resize(view.size)
+	l.hover.Hide()
+	view.layers.Add(l.hover)
+
+	l.tipTxt = canvas.NewText("", TextWhite)
+	l.tipTxt.TextSize = 12
+	l.tipTxt.TextStyle = fyne.TextStyle{Bold: true}
+	tipBg := canvas.NewRectangle(CardBg)
+	tipBg.StrokeColor = AccentPink
+	tipBg.StrokeWidth = 1
+	tipBg.CornerRadius = 4
+	l.tip = container.NewStack(tipBg, container.NewPadded(l.tipTxt))
+	l.tip.Hide()
+	view.overlay.Add(l.tip)
+
+	l.title = canvas.NewText("", TextWhite)
+	l.title.TextSize = 12
+	l.title.TextStyle = fyne.TextStyle{Bold: true}
+	l.rows = container.NewVBox()
+	legendBg := canvas.NewRectangle(color.NRGBA{R: BgDarker.R, G: BgDarker.G, B: BgDarker.B, A: 220})
+	legendBg.CornerRadius = 4
+	l.legend = container.NewStack(legendBg, container.NewPadded(container.NewVBox(l.title, l.rows)))
+	l.legend.Hide()
+	return l
+}
+
+// carte avec la légende en bas à gauche
+func (l *choroplethLayer) withLegend(mapObj fyne.CanvasObject) fyne.CanvasObject {
+	return container.NewStack(mapObj, container.NewVBox(
+		layout.NewSpacer(),
+		container.NewHBox(container.NewPadded(l.legend), layout.NewSpacer()),
+	))
+}
+
+// change le mode ; le rendu des pays se fait en arrière-plan
+func (l *choroplethLayer) setMode(mode choroplethMode) {
+	l.mode = mode
+	l.gen++
+	l.hideCount()
+	if mode == choroplethOff {
+		if l.image != nil {
+			l.view.layers.Remove(l.image)
+			l.image = nil
+		}
+		l.hover.Hide()
+		l.legend.Hide()
+		return
+	}
+
+	l.counts = countryCounts(l.data, mode)
+	maxCount := 0
+	for _, n := range l.counts {
+		maxCount = max(maxCount, n)
+	}
+	l.breaks = choroplethBreaks(maxCount)
+	l.updateLegend()
+	l.hover.Show()
+
+	gen, counts, breaks := l.gen, l.counts, l.breaks
+	go func() {
+		img := renderViewLayer(l.view, func(dst *image.RGBA, project func(lat, lon float64) (float64, float64), worldWidth, scale float64) {
+			drawCountries(dst, project, worldWidth, scale, func(c *models.CountryShape) color.Color {
+				n := counts[c.Code]
+				if n == 0 {
+					return nil
+				}
+				return choroplethColor(choroplethClass(n, breaks), len(breaks))
+			}, choroplethBorder)
+		})
+		fyne.Do(func() {
+			if gen != l.gen {
+				return
+			}
+			// l'ancien rendu reste affiché jusqu'ici (pas de clignotement)
+			if l.image != nil {
+				l.view.layers.Remove(l.image)
+			}
+			l.image = canvas.NewImageFromImage(img)
+			l.image.FillMode = canvas.ImageFillStretch
+			l.image.Resize(l.view.size)
+			// sous le calque de survol
+			l.view.layers.Objects = append([]fyne.CanvasObject{l.image}, l.view.layers.Objects...)
+			l.view.layers.Refresh()
+		})
+	}()
+}
+
+// recalcule les comptes (nouveaux lieux géocodés)
+func (l *choroplethLayer) refresh() {
+	if l.mode != choroplethOff {
+		l.setMode(l.mode)
+	}
+}
+
+// une ligne par classe : pastille + intervalle
+func (l *choroplethLayer) updateLegend() {
+	if l.mode == choroplethConcerts {
+		l.title.Text = T().ChoroplethConcerts
+	} else {
+		l.title.Text = T().ChoroplethArtists
+	}
+
+	l.rows.RemoveAll()
+	if len(l.counts) == 0 {
+		l.rows.Add(widget.NewLabel(T().NoLocations))
+	}
+	low := 1
+	for i, b := range l.breaks {
+		if len(l.counts) == 0 {
+			break
+		}
+		swatch := canvas.NewRectangle(choroplethColor(i, len(l.breaks)))
+		swatch.SetMinSize(fyne.NewSize(18, 12))
+		label := fmt.Sprintf("%d", b)
+		if b > low {
+			label = fmt.Sprintf("%d – %d", low, b)
+		}
+		text := canvas.NewText(label, TextLight)
+		text.TextSize = 11
+		l.rows.Add(container.NewHBox(container.NewCenter(swatch), text))
+		low = b + 1
+	}
+	l.legend.Show()
+	l.legend.Refresh()
+}
+
+// compte du pays sous le curseur
+func (l *choroplethLayer) showCount(pos fyne.Position) {
+	lat, lon := l.view.unproject(pos)
+	c := models.CountryAt(lat, lon)
+	if c == nil {
+		l.hideCount()
+		return
+	}
+
+	format := T().CountryConcertsFmt
+	if l.mode == choroplethArtists {
+		format = T().CountryArtistsFmt
+	}
+	l.tipTxt.Text = fmt.Sprintf(format, c.Name, l.counts[c.Code])
+	l.tipTxt.Refresh()
+
+	size := l.tip.MinSize()
+	l.tip.Resize(size)
+	x, y := pos.X+14, pos.Y+14
+	if x+size.Width > l.view.size.Width {
+		x = pos.X - 14 - size.Width
+	}
+	y = max(0, min(y, l.view.size.Height-size.Height))
+	l.tip.Move(fyne.NewPos(x, y))
+	l.tip.Show()
+}
+
+func (l *choroplethLayer) hideCount() {
+	l.tip.Hide()
+}
+
+// calque transparent qui suit le curseur (sans bloquer les clics des calques du dessus)
+type hoverLayer struct {
+	widget.BaseWidget
+	onMove func(fyne.Position)
+	onOut  func()
+}
+
+func newHoverLayer(onMove func(fyne.Position), onOut func()) *hoverLayer {
+	h := &hoverLayer{onMove: onMove, onOut: onOut}
+	h.ExtendBaseWidget(h)
+	return h
+}
+
+func (h *hoverLayer) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+func (h *hoverLayer) MouseIn(e *desktop.MouseEvent) { h.MouseMoved(e) }
+
+func (h *hoverLayer) MouseMoved(e *desktop.MouseEvent) {
+	if h.onMove != nil {
+		h.onMove(e.Position)
+	}
+}
+
+func (h *hoverLayer) MouseOut() {
+	if h.onOut != nil {
+		h.onOut()
+	}
+}
+
+var _ desktop.Hoverable = (*hoverLayer)(nil)
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// erreur de construction de la page carte, avec l'étape en cause
+type mapStageError struct {
+	stage string
+	err   error
+}
+
+func (e *mapStageError) Error() string {
+	return fmt.Sprintf(T().MapStageErrorFmt, e.stage, e.err)
+}
+
+func (e *mapStageError) Unwrap() error { return e.err }
+
+// exécute une étape de la page ; une panique est rattrapée et devient
+// une erreur qui nomme l'étape
+func runMapStage(stage string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Erreur à l'étape %s: %v\n%s", stage, r, debug.Stack())
+			err = &mapStageError{stage: stage, err: fmt.Errorf("%v", r)}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// vue d'erreur de la carte : cause, bouton réessayer et, si onOffline n'est pas nil,
+// ouverture avec les données locales
+func mapErrorView(err error, onBack, onRetry, onOffline func()) fyne.CanvasObject {
+	backButton := widget.NewButton(T().Back, onBack)
+	backButton.Importance = widget.HighImportance
+
+	title := widget.NewLabel("⚠ " + T().MapLoadFailed)
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.Alignment = fyne.TextAlignCenter
+
+	cause := widget.NewLabel(err.Error())
+	cause.Alignment = fyne.TextAlignCenter
+
+	retryBtn := widget.NewButton(T().Retry, onRetry)
+	retryBtn.Importance = widget.HighImportance
+	buttons := container.NewHBox(retryBtn)
+	if onOffline != nil {
+		buttons.Add(widget.NewButton(T().UseOfflineData, onOffline))
+	}
+
+	return container.NewBorder(
+		container.NewHBox(backButton), nil, nil, nil,
+		container.NewCenter(container.NewVBox(title, cause, container.NewCenter(buttons))),
+	)
+}
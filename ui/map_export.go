@@ -0,0 +1,503 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// résolutions proposées pour l'export image
+var mapExportSizes = []struct {
+	Label  string
+	Width  int
+	Height int
+}{
+	{"1280 × 720", 1280, 720},
+	{"1920 × 1080", 1920, 1080},
+	{"2560 × 1440", 2560, 1440},
+	{"3840 × 2160", 3840, 2160},
+}
+
+// attribution obligatoire pour les tuiles OSM
+const osmAttribution = "© OpenStreetMap contributors"
+
+// vue hors écran : origine en pixels "monde" au zoom choisi
+type exportViewport struct {
+	zoom    int
+	originX float64
+	originY float64
+	width   int
+	height  int
+}
+
+// lat/lon -> pixel dans l'image exportée
+func (v exportViewport) project(lat, lon float64) (float64, float64) {
+	tx, ty := latLonToTileXY(lat, lon, v.zoom)
+	return tx*256 - v.originX, ty*256 - v.originY
+}
+
+// ExportMapImage géocode les concerts et écrit la carte en PNG sans ouvrir de fenêtre
+func ExportMapImage(artists []models.Artist, path string, width, height int) error {
+	data, err := loadMapData(artists, func(status string) {
+		log.Println(status)
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("impossible de créer %s: %v", path, err)
+	}
+	defer f.Close()
+
+	return writeMapPNG(f, data, width, height)
+}
+
+// rendu + encodage PNG
+func writeMapPNG(w io.Writer, data *mapData, width, height int) error {
+	img, err := renderMapImage(data, width, height)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// rendu hors écran de la carte : tuiles, tournées, marqueurs, légende
+func renderMapImage(data *mapData, width, height int) (*image.RGBA, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("résolution invalide: %dx%d", width, height)
+	}
+	if data == nil || len(data.locations) == 0 {
+		return nil, fmt.Errorf("%s", T().NoLocations)
+	}
+
+	vp := fitExportViewport(data.locations, width, height)
+	log.Printf("Export map %dx%d at zoom %d\n", width, height, vp.zoom)
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// facteur d'échelle par rapport à une sortie 1280 px
+	scale := math.Max(1, float64(width)/1280)
+
+	// fond vectoriel toujours dessiné (zones sans tuile, hors ligne)
+	drawBasemap(img, vp.project, float64(int(tileSize)<<vp.zoom), scale)
+	tiles := !useVectorBasemap()
+	if tiles {
+		drawExportTiles(img, vp)
+	}
+	drawExportRoutes(img, vp, data, scale)
+	drawExportMarkers(img, vp, data.locations, scale)
+	drawExportLegend(img, data, scale)
+	if tiles {
+		drawExportAttribution(img, scale)
+	}
+
+	return img, nil
+}
+
+// choisit le zoom le plus fort qui fait tenir tous les lieux dans l'image
+func fitExportViewport(locations []*models.LocationCoords, width, height int) exportViewport {
+	minLat, maxLat := locations[0].Latitude, locations[0].Latitude
+	minLon, maxLon := locations[0].Longitude, locations[0].Longitude
+	for _, loc := range locations {
+		minLat = math.Min(minLat, loc.Latitude)
+		maxLat = math.Max(maxLat, loc.Latitude)
+		minLon = math.Min(minLon, loc.Longitude)
+		maxLon = math.Max(maxLon, loc.Longitude)
+	}
+
+	zoom := 1
+	for z := 8; z >= 1; z-- {
+		x1, y1 := latLonToTileXY(maxLat, minLon, z)
+		x2, y2 := latLonToTileXY(minLat, maxLon, z)
+		if (x2-x1)*256 <= float64(width)*0.85 && (y2-y1)*256 <= float64(height)*0.85 {
+			zoom = z
+			break
+		}
+	}
+
+	x1, y1 := latLonToTileXY(maxLat, minLon, zoom)
+	x2, y2 := latLonToTileXY(minLat, maxLon, zoom)
+	return exportViewport{
+		zoom:    zoom,
+		originX: (x1+x2)/2*256 - float64(width)/2,
+		originY: (y1+y2)/2*256 - float64(height)/2,
+		width:   width,
+		height:  height,
+	}
+}
+
+// télécharge (ou lit en cache) et colle les tuiles visibles
+func drawExportTiles(dst *image.RGBA, vp exportViewport) {
+	const tileSize = 256
+	n := 1 << vp.zoom
+
+	txMin := int(math.Floor(vp.originX / tileSize))
+	txMax := int(math.Floor((vp.originX + float64(vp.width) - 1) / tileSize))
+	tyMin := int(math.Floor(vp.originY / tileSize))
+	tyMax := int(math.Floor((vp.originY + float64(vp.height) - 1) / tileSize))
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	semaphore := make(chan struct{}, 4)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	loaded := 0
+
+	for tx := txMin; tx <= txMax; tx++ {
+		for ty := tyMin; ty <= tyMax; ty++ {
+			if ty < 0 || ty >= n {
+				continue
+			}
+			wg.Add(1)
+			go func(tx, ty int) {
+				defer wg.Done()
+				semaphore <- struct{}{}        // acquire
+				defer func() { <-semaphore }() // release
+
+				// le monde se répète horizontalement
+				wx := ((tx % n) + n) % n
+				tile := loadTileImage(client, vp.zoom, wx, ty)
+				if tile == nil {
+					return
+				}
+
+				px := int(math.Round(float64(tx*tileSize) - vp.originX))
+				py := int(math.Round(float64(ty*tileSize) - vp.originY))
+				r := image.Rect(px, py, px+tileSize, py+tileSize)
+
+				mu.Lock()
+				draw.Draw(dst, r, tile, tile.Bounds().Min, draw.Src)
+				loaded++
+				mu.Unlock()
+			}(tx, ty)
+		}
+	}
+	wg.Wait()
+	log.Printf("Export map: %d tiles drawn\n", loaded)
+}
+
+// tuile décodée depuis le cache disque ou le serveur OSM, dans le style choisi
+func loadTileImage(client *http.Client, zoom, x, y int) image.Image {
+	b := rawTileBytes(client, zoom, x, y)
+	if b == nil {
+		return nil
+	}
+	img := decodeTile(b, zoom, x, y)
+	if img == nil {
+		return nil
+	}
+	return styleTileImage(img, currentTileStyle())
+}
+
+// tournées chronologiques par artiste
+func (d *mapData) tours() map[string][]models.TourStop {
+	coords := make(map[string]*models.LocationCoords, len(d.locations))
+	for _, loc := range d.locations {
+		coords[loc.Lieux] = loc
+	}
+
+	tours := make(map[string][]models.TourStop)
+	for location, concerts := range d.concertsByLocation {
+		loc, ok := coords[location]
+		if !ok {
+			continue
+		}
+		for _, concert := range concerts {
+			for _, date := range concert.Dates {
+				t, err := models.ParseConcertDate(date)
+				if err != nil {
+					continue
+				}
+				tours[concert.Artist] = append(tours[concert.Artist], models.TourStop{
+					Location:  location,
+					Latitude:  loc.Latitude,
+					Longitude: loc.Longitude,
+					Date:      t,
+				})
+			}
+		}
+	}
+	for artist := range tours {
+		models.SortTour(tours[artist])
+	}
+	return tours
+}
+
+// couleur de tournée (teinte répartie selon l'index)
+func routeColor(i, n int) color.NRGBA {
+	if n <= 0 {
+		n = 1
+	}
+	h := float64(i) / float64(n) * 6
+	x := uint8(255 * (1 - math.Abs(math.Mod(h, 2)-1)))
+	switch int(h) {
+	case 0:
+		return color.NRGBA{255, x, 0, 255}
+	case 1:
+		return color.NRGBA{x, 255, 0, 255}
+	case 2:
+		return color.NRGBA{0, 255, x, 255}
+	case 3:
+		return color.NRGBA{0, x, 255, 255}
+	case 4:
+		return color.NRGBA{x, 0, 255, 255}
+	default:
+		return color.NRGBA{255, 0, x, 255}
+	}
+}
+
+// lignes des tournées, une couleur par artiste
+func drawExportRoutes(dst *image.RGBA, vp exportViewport, data *mapData, scale float64) {
+	tours := data.tours()
+	artists := make([]string, 0, len(tours))
+	for artist := range tours {
+		artists = append(artists, artist)
+	}
+	sort.Strings(artists)
+
+	for i, artist := range artists {
+		col := routeColor(i, len(artists))
+		col.A = 170
+		stops := tours[artist]
+		for j := 1; j < len(stops); j++ {
+			if stops[j].Location == stops[j-1].Location {
+				continue
+			}
+			x1, y1 := vp.project(stops[j-1].Latitude, stops[j-1].Longitude)
+			x2, y2 := vp.project(stops[j].Latitude, stops[j].Longitude)
+			strokeLine(dst, x1, y1, x2, y2, 1.5*scale, col)
+		}
+	}
+}
+
+// marqueurs des lieux (même style que la carte à l'écran)
+func drawExportMarkers(dst *image.RGBA, vp exportViewport, locations []*models.LocationCoords, scale float64) {
+	r := 6 * scale
+	for _, loc := range locations {
+		x, y := vp.project(loc.Latitude, loc.Longitude)
+		fillCircle(dst, x, y, r+scale, AccentCyan)
+		fillCircle(dst, x, y, r, AccentPink)
+	}
+}
+
+// légende en bas à gauche
+func drawExportLegend(dst *image.RGBA, data *mapData, scale float64) {
+	titleFace, err := exportFontFace(18*scale, true)
+	if err != nil {
+		log.Printf("[EXPORT FONT] %v\n", err)
+		return
+	}
+	defer titleFace.Close()
+	face, err := exportFontFace(14*scale, false)
+	if err != nil {
+		log.Printf("[EXPORT FONT] %v\n", err)
+		return
+	}
+	defer face.Close()
+
+	tours := data.tours()
+	lines := []string{
+		fmt.Sprintf(T().LocationsCountFmt, len(data.locations)),
+		fmt.Sprintf(T().LegendToursFmt, len(tours)),
+	}
+	title := T().WindowTitle + " - " + T().Map
+
+	pad := 12 * scale
+	lineH := 22 * scale
+	swatch := 24 * scale
+	boxW := float64(font.MeasureString(titleFace, title).Ceil())
+	for _, l := range lines {
+		boxW = math.Max(boxW, swatch+pad+float64(font.MeasureString(face, l).Ceil()))
+	}
+	boxW += 2 * pad
+	boxH := 2*pad + lineH*float64(len(lines)+1)
+
+	x0 := pad
+	y0 := float64(dst.Bounds().Dy()) - boxH - pad - 20*scale
+	bg := color.NRGBA{R: BgDarker.R, G: BgDarker.G, B: BgDarker.B, A: 220}
+	fillPolygon(dst, [][2]float64{{x0, y0}, {x0 + boxW, y0}, {x0 + boxW, y0 + boxH}, {x0, y0 + boxH}}, bg)
+
+	drawExportText(dst, titleFace, x0+pad, y0+pad+lineH*0.75, title, TextWhite)
+
+	// marqueur
+	cy := y0 + pad + lineH*1.5
+	fillCircle(dst, x0+pad+swatch/2, cy, 7*scale, AccentCyan)
+	fillCircle(dst, x0+pad+swatch/2, cy, 6*scale, AccentPink)
+	drawExportText(dst, face, x0+2*pad+swatch, cy+5*scale, lines[0], TextLight)
+
+	// tournées
+	cy += lineH
+	for i := 0; i < 4; i++ {
+		seg := swatch / 4
+		strokeLine(dst, x0+pad+float64(i)*seg, cy, x0+pad+float64(i+1)*seg, cy, 2*scale, routeColor(i, 4))
+	}
+	drawExportText(dst, face, x0+2*pad+swatch, cy+5*scale, lines[1], TextLight)
+}
+
+// attribution OSM en bas à droite
+func drawExportAttribution(dst *image.RGBA, scale float64) {
+	face, err := exportFontFace(12*scale, false)
+	if err != nil {
+		log.Printf("[EXPORT FONT] %v\n", err)
+		return
+	}
+	defer face.Close()
+
+	pad := 6 * scale
+	w := float64(font.MeasureString(face, osmAttribution).Ceil())
+	h := 18 * scale
+	x1 := float64(dst.Bounds().Dx())
+	y1 := float64(dst.Bounds().Dy())
+	bg := color.NRGBA{R: 255, G: 255, B: 255, A: 200}
+	fillPolygon(dst, [][2]float64{{x1 - w - 2*pad, y1 - h}, {x1, y1 - h}, {x1, y1}, {x1 - w - 2*pad, y1}}, bg)
+	drawExportText(dst, face, x1-w-pad, y1-5*scale, osmAttribution, TextBlack)
+}
+
+// police du thème fyne pour le texte de l'export
+func exportFontFace(size float64, bold bool) (font.Face, error) {
+	res := theme.DefaultTheme().Font(fyne.TextStyle{Bold: bold})
+	f, err := opentype.Parse(res.Content())
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// texte à la position (x = gauche, y = ligne de base)
+func drawExportText(dst draw.Image, face font.Face, x, y float64, text string, col color.Color) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)},
+	}
+	d.DrawString(text)
+}
+
+// remplit un polygone antialiasé (rasterizer limité à sa boîte englobante)
+func fillPolygon(dst *image.RGBA, pts [][2]float64, col color.Color) {
+	if len(pts) < 3 {
+		return
+	}
+	minX, minY := pts[0][0], pts[0][1]
+	maxX, maxY := minX, minY
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+		minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+	}
+
+	box := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX))+1, int(math.Ceil(maxY))+1)
+	box = box.Intersect(dst.Bounds())
+	if box.Empty() {
+		return
+	}
+
+	r := vector.NewRasterizer(box.Dx(), box.Dy())
+	r.DrawOp = draw.Over
+	ox, oy := float64(box.Min.X), float64(box.Min.Y)
+	r.MoveTo(float32(pts[0][0]-ox), float32(pts[0][1]-oy))
+	for _, p := range pts[1:] {
+		r.LineTo(float32(p[0]-ox), float32(p[1]-oy))
+	}
+	r.ClosePath()
+	r.Draw(dst, box, image.NewUniform(col), image.Point{})
+}
+
+// disque plein
+func fillCircle(dst *image.RGBA, cx, cy, radius float64, col color.Color) {
+	const steps = 32
+	pts := make([][2]float64, steps)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / steps
+		pts[i] = [2]float64{cx + radius*math.Cos(a), cy + radius*math.Sin(a)}
+	}
+	fillPolygon(dst, pts, col)
+}
+
+// segment épais (quadrilatère)
+func strokeLine(dst *image.RGBA, x1, y1, x2, y2, width float64, col color.Color) {
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	nx, ny := -dy/length*width/2, dx/length*width/2
+	fillPolygon(dst, [][2]float64{
+		{x1 + nx, y1 + ny},
+		{x2 + nx, y2 + ny},
+		{x2 - nx, y2 - ny},
+		{x1 - nx, y1 - ny},
+	}, col)
+}
+
+// dialogue d'export : choix de la résolution puis du fichier
+func showMapExportDialog(win fyne.Window, data *mapData) {
+	labels := make([]string, len(mapExportSizes))
+	for i, s := range mapExportSizes {
+		labels[i] = s.Label
+	}
+	sizeSelect := widget.NewSelect(labels, nil)
+	sizeSelect.SetSelectedIndex(1)
+
+	content := container.NewVBox(widget.NewLabel(T().ExportResolution), sizeSelect)
+	dialog.ShowCustomConfirm(T().ExportImage, T().Export, T().Cancel, content, func(ok bool) {
+		if !ok || sizeSelect.SelectedIndex() < 0 {
+			return
+		}
+		size := mapExportSizes[sizeSelect.SelectedIndex()]
+
+		save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if w == nil {
+				return // annulé
+			}
+
+			progress := dialog.NewCustomWithoutButtons(T().ExportImage, widget.NewProgressBarInfinite(), win)
+			progress.Show()
+
+			go func() {
+				err := writeMapPNG(w, data, size.Width, size.Height)
+				if cerr := w.Close(); err == nil {
+					err = cerr
+				}
+				fyne.Do(func() {
+					progress.Hide()
+					if err != nil {
+						dialog.ShowError(err, win)
+						return
+					}
+					dialog.ShowInformation(T().ExportImage, fmt.Sprintf(T().ExportSavedFmt, w.URI().Name()), win)
+				})
+			}()
+		}, win)
+		save.SetFileName("groupie-tracker-map.png")
+		save.SetFilter(storage.NewExtensionFileFilter([]string{".png"}))
+		save.Show()
+	}, win)
+}
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"log"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// lieux à géocoder et concerts par lieu (avant géocodage)
+type mapSources struct {
+	places             []string
+	concertsByLocation map[string][]ConcertInfo
+}
+
+// charge relations + locations via l'api et associe les concerts aux lieux
+func fetchMapSources(artists []models.Artist) (*mapSources, error) {
+	relations, err := models.FetchRelations()
+	if err != nil {
+		return nil, fmt.Errorf("Impossible de charger les relations: %v", err)
+	}
+	log.Printf("✓ Relations loaded: %d artists\n", len(relations.Index))
+
+	locations, err := models.FetchLocations()
+	if err != nil {
+		return nil, fmt.Errorf("Impossible de charger les locations: %v", err)
+	}
+	log.Printf("✓ Locations loaded: %d location entries\n", len(locations.Index))
+
+	return buildMapSources(artists, relations, locations), nil
+}
+
+// sources de secours quand l'api ne répond pas : dernière réponse gardée sur disque,
+// sinon les lieux déjà connus des artistes (sans dates) ; nil si rien n'est disponible.
+// saved : date de la copie (zéro pour les lieux des artistes)
+func cachedMapSources(artists []models.Artist) (src *mapSources, saved time.Time) {
+	relations, relSaved, errRel := models.LoadRelationsSnapshot()
+	locations, locSaved, errLoc := models.LoadLocationsSnapshot()
+	if errRel == nil && errLoc == nil {
+		if locSaved.Before(relSaved) {
+			relSaved = locSaved
+		}
+		return buildMapSources(artists, relations, locations), relSaved
+	}
+	log.Printf("Pas de copie locale de l'api: %v / %v\n", errRel, errLoc)
+
+	src = &mapSources{concertsByLocation: make(map[string][]ConcertInfo)}
+	src.places = uniqueLocationKeys(artists)
+	for _, a := range artists {
+		for _, place := range a.LocationsList {
+			src.concertsByLocation[place] = append(src.concertsByLocation[place], ConcertInfo{ArtistID: a.ID, Artist: a.Name})
+		}
+	}
+	if len(src.places) == 0 {
+		return nil, time.Time{}
+	}
+	return src, time.Time{}
+}
+
+// lieux uniques (ordre de l'api) et concerts des artistes par lieu
+func buildMapSources(artists []models.Artist, relations *models.RelationData, locations *models.LocationData) *mapSources {
+	src := &mapSources{concertsByLocation: make(map[string][]ConcertInfo)}
+
+	// lieux uniques, dans l'ordre de l'api
+	seen := make(map[string]bool)
+	for _, loc := range locations.Index {
+		for _, place := range loc.Locations {
+			if !seen[place] {
+				seen[place] = true
+				src.places = append(src.places, place)
+			}
+		}
+	}
+
+	for _, artist := range artists {
+		for _, rel := range relations.Index {
+			if rel.ID != artist.ID {
+				continue
+			}
+			for location, dates := range rel.DatesLocations {
+				src.concertsByLocation[location] = append(src.concertsByLocation[location], ConcertInfo{
+					ArtistID: artist.ID,
+					Artist:   artist.Name,
+					Dates:    dates,
+				})
+			}
+			break
+		}
+	}
+	return src
+}
+
+// requêtes Nominatim de toute l'app (carte, relances, près de moi, distances,
+// ma position, recherche) : une à la fois, une par seconde au plus
+var nominatimSem = make(chan struct{}, 1)
+
+// attend son tour ; la fonction rendue libère la place une seconde après le début
+func nominatimSlot() (release func()) {
+	nominatimSem <- struct{}{} // acquire
+	start := time.Now()
+	return func() {
+		time.Sleep(time.Until(start.Add(time.Second)))
+		<-nominatimSem
+	}
+}
+
+// géocode les lieux un par un (requêtes limitées par nominatimSlot) et s'arrête
+// dès que done est fermé (nil : jusqu'au bout) ; onResult est appelé depuis le
+// goroutine appelant pour chaque lieu, avec coords nil en cas d'échec
+func geocodePlaces(done <-chan struct{}, places []string, onResult func(place string, coords *models.LocationCoords)) {
+	for _, place := range places {
+		select {
+		case <-done:
+			log.Println("Géocodage interrompu: page quittée")
+			return
+		default:
+		}
+
+		coords := geocodeLocationFast(place)
+		if coords == nil || coords.Latitude == 0 || coords.Longitude == 0 {
+			log.Printf("✗ Geocode failed for: %s\n", place)
+			onResult(place, nil)
+			continue
+		}
+		log.Printf("✓ Geocoded: %s -> (%.4f, %.4f)\n", place, coords.Latitude, coords.Longitude)
+		onResult(place, coords)
+	}
+}
+
+// coords déjà en cache (cadrage initial de la carte), emprise du monde si aucune
+func cachedPlaceCoords(places []string) []*models.LocationCoords {
+	var coords []*models.LocationCoords
+	for _, place := range places {
+		if c := models.GetCachedCoords(place); c != nil && c.Latitude != 0 && c.Longitude != 0 {
+			coords = append(coords, c)
+		}
+	}
+	if len(coords) == 0 {
+		coords = []*models.LocationCoords{
+			{Lieux: "world-nw", Latitude: 72, Longitude: -170},
+			{Lieux: "world-se", Latitude: -55, Longitude: 170},
+		}
+	}
+	return coords
+}
+
+// progression du géocodage : barre "37/52 lieux géocodés" et lieux en échec à relancer
+type mapProgress struct {
+	box      *fyne.Container
+	bar      *widget.ProgressBar
+	failed   *widget.Accordion
+	item     *widget.AccordionItem
+	rows     *fyne.Container
+	total    int
+	done     int
+	pending  map[string]bool // lieux en échec
+	inFlight map[string]bool // relances en cours
+
+	onRetry func(place string, done func(ok bool)) // relance d'un lieu
+}
+
+func newMapProgress(total int, onRetry func(place string, done func(ok bool))) *mapProgress {
+	p := &mapProgress{total: total, pending: make(map[string]bool), inFlight: make(map[string]bool), onRetry: onRetry}
+
+	p.bar = widget.NewProgressBar()
+	p.bar.Max = float64(max(total, 1))
+	p.bar.TextFormatter = func() string {
+		return fmt.Sprintf(T().GeocodedFmt, p.done, p.total)
+	}
+
+	retryAll := widget.NewButton(T().RetryAll, func() {
+		for place := range p.pending {
+			p.retry(place)
+		}
+	})
+	p.rows = container.NewVBox()
+	scroll := container.NewVScroll(p.rows)
+	scroll.SetMinSize(fyne.NewSize(0, 120))
+	p.item = widget.NewAccordionItem("", container.NewBorder(retryAll, nil, nil, nil, scroll))
+	p.failed = widget.NewAccordion(p.item)
+	p.failed.Hide()
+
+	p.box = container.NewVBox(p.bar, p.failed)
+	return p
+}
+
+// un lieu traité (coords nil : échec)
+func (p *mapProgress) step(place string, ok bool) {
+	p.done++
+	p.bar.SetValue(float64(p.done))
+	if !ok {
+		p.pending[place] = true
+		p.refreshFailed()
+	}
+	if p.done >= p.total {
+		p.bar.Hide()
+		if len(p.pending) == 0 {
+			p.box.Hide()
+		}
+	}
+}
+
+// relance le géocodage d'un lieu en échec (une seule relance à la fois par lieu)
+func (p *mapProgress) retry(place string) {
+	if !p.pending[place] || p.inFlight[place] || p.onRetry == nil {
+		return
+	}
+	p.inFlight[place] = true
+	p.refreshFailed()
+	p.onRetry(place, func(ok bool) {
+		delete(p.inFlight, place)
+		if ok {
+			delete(p.pending, place)
+		}
+		p.refreshFailed()
+	})
+}
+
+// liste des lieux en échec, un bouton "réessayer" par lieu
+func (p *mapProgress) refreshFailed() {
+	places := make([]string, 0, len(p.pending))
+	for place := range p.pending {
+		places = append(places, place)
+	}
+	sort.Strings(places)
+
+	p.rows.RemoveAll()
+	for _, place := range places {
+		name := place
+		btn := widget.NewButton(T().Retry, nil)
+		btn.Importance = widget.LowImportance
+		btn.OnTapped = func() { p.retry(name) }
+		if p.inFlight[name] {
+			btn.Disable()
+		}
+		p.rows.Add(container.NewHBox(widget.NewLabel("✗ "+displayLocationName(name)), layout.NewSpacer(), btn))
+	}
+
+	p.item.Title = fmt.Sprintf(T().FailedPlacesFmt, len(p.pending))
+	if len(p.pending) == 0 {
+		p.failed.Hide()
+		if p.done >= p.total {
+			p.box.Hide()
+		}
+	} else {
+		p.failed.Show()
+	}
+	p.failed.Refresh()
+}
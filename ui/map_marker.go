@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// zone cliquable autour du point
+var markerHitSize = fyne.NewSize(24, 24)
+
+// marqueur de lieu : point coloré, aperçu au survol (desktop), détails au clic
+type locationMarker struct {
+	widget.BaseWidget
+	dot     *canvas.Circle
+	dotSize float32
+
+	onHover  func(in bool)
+	onTapped func()
+}
+
+func newLocationMarker() *locationMarker {
+	m := &locationMarker{dot: canvas.NewCircle(AccentPink), dotSize: 12}
+	m.dot.StrokeColor = AccentCyan
+	m.dot.StrokeWidth = 1
+	m.ExtendBaseWidget(m)
+	return m
+}
+
+// met le marqueur en avant (plus gros, cyan)
+func (m *locationMarker) setHighlighted(on bool) {
+	if on {
+		m.dot.FillColor = AccentCyan
+		m.dot.StrokeColor = TextWhite
+		m.dotSize = 18
+	} else {
+		m.dot.FillColor = AccentPink
+		m.dot.StrokeColor = AccentCyan
+		m.dotSize = 12
+	}
+	m.Refresh()
+}
+
+func (m *locationMarker) CreateRenderer() fyne.WidgetRenderer {
+	return &locationMarkerRenderer{m: m}
+}
+
+func (m *locationMarker) Tapped(*fyne.PointEvent) {
+	if m.onTapped != nil {
+		m.onTapped()
+	}
+}
+
+func (m *locationMarker) MouseIn(*desktop.MouseEvent) {
+	if m.onHover != nil {
+		m.onHover(true)
+	}
+}
+
+func (m *locationMarker) MouseMoved(*desktop.MouseEvent) {}
+
+func (m *locationMarker) MouseOut() {
+	if m.onHover != nil {
+		m.onHover(false)
+	}
+}
+
+var _ desktop.Hoverable = (*locationMarker)(nil)
+
+type locationMarkerRenderer struct {
+	m *locationMarker
+}
+
+// point centré dans la zone cliquable
+func (r *locationMarkerRenderer) Layout(size fyne.Size) {
+	d := r.m.dotSize
+	r.m.dot.Resize(fyne.NewSize(d, d))
+	r.m.dot.Move(fyne.NewPos((size.Width-d)/2, (size.Height-d)/2))
+}
+
+func (r *locationMarkerRenderer) MinSize() fyne.Size { return markerHitSize }
+
+func (r *locationMarkerRenderer) Refresh() {
+	r.Layout(r.m.Size())
+	r.m.dot.Refresh()
+}
+
+func (r *locationMarkerRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.m.dot}
+}
+
+func (r *locationMarkerRenderer) Destroy() {}
+
+// aperçu compact d'un lieu (un seul à la fois, dans le calque overlay)
+type markerPreview struct {
+	box     *fyne.Container
+	name    *canvas.Text
+	summary *canvas.Text
+	view    *mapView
+}
+
+func newMarkerPreview(view *mapView) *markerPreview {
+	p := &markerPreview{view: view}
+
+	p.name = canvas.NewText("", TextWhite)
+	p.name.TextSize = 12
+	p.name.TextStyle = fyne.TextStyle{Bold: true}
+	p.summary = canvas.NewText("", TextLight)
+	p.summary.TextSize = 11
+
+	bg := canvas.NewRectangle(CardBg)
+	bg.StrokeColor = AccentCyan
+	bg.StrokeWidth = 1
+	bg.CornerRadius = 4
+
+	p.box = container.NewStack(bg, container.NewPadded(container.NewVBox(p.name, p.summary)))
+	p.box.Hide()
+	view.overlay.Add(p.box)
+	return p
+}
+
+// affiche l'aperçu à côté du point, sans déborder de la carte
+func (p *markerPreview) show(location string, concerts []ConcertInfo, lat, lon float64) {
+	dates := 0
+	for _, c := range concerts {
+		dates += len(c.Dates)
+	}
+	p.name.Text = displayLocationName(location)
+	p.summary.Text = fmt.Sprintf(T().MarkerSummaryFmt, len(concerts), dates)
+
+	size := p.box.MinSize()
+	p.box.Resize(size)
+
+	pos := p.view.project(lat, lon)
+	x, y := pos.X+14, pos.Y-size.Height/2
+	if x+size.Width > p.view.size.Width {
+		x = pos.X - 14 - size.Width
+	}
+	y = max(0, min(y, p.view.size.Height-size.Height))
+	p.box.Move(fyne.NewPos(x, y))
+
+	p.box.Show()
+	p.box.Refresh()
+}
+
+func (p *markerPreview) hide() {
+	p.box.Hide()
+}
+
+// panneau de détails ancré à droite de la carte
+type markerDetails struct {
+	panel    *fyne.Container
+	title    *widget.Label
+	items    *fyne.Container
+	concerts map[string][]ConcertInfo
+	artists  map[int]models.Artist
+	onArtist func(models.Artist)
+}
+
+func newMarkerDetails(concerts map[string][]ConcertInfo, artists []models.Artist, onArtist func(models.Artist)) *markerDetails {
+	d := &markerDetails{
+		concerts: concerts,
+		artists:  make(map[int]models.Artist),
+		onArtist: onArtist,
+	}
+	for _, a := range artists {
+		d.artists[a.ID] = a
+	}
+
+	d.title = widget.NewLabel("")
+	d.title.TextStyle = fyne.TextStyle{Bold: true}
+	d.title.Wrapping = fyne.TextWrapWord
+	closeBtn := widget.NewButton("✕", d.hide)
+	closeBtn.Importance = widget.LowImportance
+
+	d.items = container.NewVBox()
+
+	// largeur fixe du panneau
+	sizer := canvas.NewRectangle(CardBg)
+	sizer.SetMinSize(fyne.NewSize(300, 0))
+
+	d.panel = container.NewStack(sizer, container.NewBorder(
+		container.NewVBox(container.NewBorder(nil, nil, nil, closeBtn, d.title), widget.NewSeparator()),
+		nil, nil, nil,
+		container.NewVScroll(d.items),
+	))
+	d.panel.Hide()
+	return d
+}
+
+// remplit le panneau pour un lieu : artistes (lien vers leur page) et dates triées
+func (d *markerDetails) show(location string) {
+	d.title.SetText(displayLocationName(location))
+
+	concerts := append([]ConcertInfo(nil), d.concerts[location]...)
+	sort.Slice(concerts, func(i, j int) bool {
+		return strings.ToLower(concerts[i].Artist) < strings.ToLower(concerts[j].Artist)
+	})
+
+	d.items.RemoveAll()
+	if len(concerts) == 0 {
+		d.items.Add(widget.NewLabel(T().NoConcerts))
+	}
+	for _, c := range concerts {
+		artistBtn := widget.NewButton("🎤 "+c.Artist+" →", nil)
+		artistBtn.Alignment = widget.ButtonAlignLeading
+		artistBtn.Importance = widget.LowImportance
+		if a, ok := d.artists[c.ArtistID]; ok && d.onArtist != nil {
+			artistBtn.OnTapped = func() { d.onArtist(a) }
+		} else {
+			artistBtn.Disable()
+		}
+		d.items.Add(artistBtn)
+
+		for _, date := range models.SortedConcertDates(c.Dates) {
+			d.items.Add(widget.NewLabel("      • " + strings.TrimPrefix(date, "*")))
+		}
+		d.items.Add(widget.NewSeparator())
+	}
+
+	d.panel.Show()
+	d.panel.Refresh()
+}
+
+func (d *markerDetails) hide() {
+	d.panel.Hide()
+}
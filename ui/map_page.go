@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"groupie-tracker/models"
-	"image/color"
 	"io"
 	"log"
 	"math"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -20,6 +20,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -35,8 +36,9 @@ type LocationCoords struct {
 
 // concert info
 type ConcertInfo struct {
-	Artist string
-	Dates  []string
+	ArtistID int
+	Artist   string
+	Dates    []string
 }
 
 type GeocodingResponse struct {
@@ -44,8 +46,8 @@ type GeocodingResponse struct {
 	Lon string `json:"lon"`
 }
 
-// page carte
-func NewMapPageWithWindow(win *Window, artists []models.Artist, onBack func()) {
+// page carte ; onArtist ouvre la page d'un artiste depuis le panneau de détails
+func NewMapPageWithWindow(win *Window, artists []models.Artist, onBack func(), onArtist func(models.Artist)) {
 	// Créer une barre de chargement simple
 	loadingLabel := widget.NewLabel(T().Loading)
 	loadingBar := widget.NewProgressBarInfinite()
@@ -63,186 +65,334 @@ func NewMapPageWithWindow(win *Window, artists []models.Artist, onBack func()) {
 
 	win.SetContent(tempContainer)
 
-	// chargement de la carte en arrière-plan
+	// chargement de la carte en arrière-plan : la carte s'affiche dès que les
+	// relations sont chargées, les marqueurs arrivent au fil du géocodage
 	go func() {
-		// récupère relations et locations via l'api
-		relations, err := models.FetchRelations()
+		src, err := fetchMapSources(artists)
 		if err != nil {
-			fyne.CurrentApp().SendNotification(&fyne.Notification{
-				Title:   T().Error,
-				Content: fmt.Sprintf("Impossible de charger les relations: %v", err),
-			})
-			return
-		}
-		log.Printf("✓ Relations loaded: %d artists\n", len(relations.Index))
-
-		locations, err := models.FetchLocations()
-		if err != nil {
-			fyne.CurrentApp().SendNotification(&fyne.Notification{
-				Title:   T().Error,
-				Content: fmt.Sprintf("Impossible de charger les locations: %v", err),
-			})
+			log.Printf("Erreur de chargement de la carte: %v\n", err)
+			showMapError(win, err, artists, onBack, onArtist)
 			return
 		}
-		log.Printf("✓ Locations loaded: %d location entries\n", len(locations.Index))
+		openMapPage(win, artists, src, "", onBack, onArtist)
+	}()
+}
 
-		fyne.Do(func() {
-			loadingLabel.SetText(T().Loading)
-		})
+// vue d'erreur avec réessayer et, si une copie locale existe, les données hors ligne
+func showMapError(win *Window, err error, artists []models.Artist, onBack func(), onArtist func(models.Artist)) {
+	retry := func() { NewMapPageWithWindow(win, artists, onBack, onArtist) }
 
-		// on construit une map des lieux avec leurs coords via geocodage (en parallèle limité)
-		locationsMap := make(map[string]*models.LocationCoords)
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-
-		// semaphore pour limiter les goroutines parallèles (Nominatim a un rate limit strict)
-		// utiliser seulement 2 goroutines pour respecter le rate limit
-		semaphore := make(chan struct{}, 2)
-
-		// collect unique locations first
-		uniqueLocations := make(map[string]bool)
-		for _, loc := range locations.Index {
-			for _, place := range loc.Locations {
-				if !uniqueLocations[place] {
-					uniqueLocations[place] = true
-					wg.Add(1)
-					go func(place string) {
-						defer wg.Done()
-						semaphore <- struct{}{}        // acquire
-						defer func() { <-semaphore }() // release
-
-						coords := geocodeLocationFast(place)
-						if coords != nil && coords.Latitude != 0 && coords.Longitude != 0 {
-							mu.Lock()
-							locationsMap[place] = coords
-							mu.Unlock()
-							log.Printf("✓ Geocoded: %s -> (%.4f, %.4f)\n", place, coords.Latitude, coords.Longitude)
-						} else {
-							log.Printf("✗ Geocode failed for: %s\n", place)
-						}
-					}(place)
-				}
-			}
+	var offline func()
+	if src, saved := cachedMapSources(artists); src != nil {
+		note := T().OfflineArtistsData
+		if !saved.IsZero() {
+			note = fmt.Sprintf(T().OfflineDataFmt, saved.Format("02/01/2006 15:04"))
 		}
-		wg.Wait()
-		log.Printf("✓ Locations map built: %d unique places\n", len(locationsMap))
-
-		// associe chaque lieu aux concerts
-		var concertLocations []*models.LocationCoords
-		concertsByLocation := make(map[string][]ConcertInfo)
-
-		matchedCount := 0
-		for _, artist := range artists {
-			for _, rel := range relations.Index {
-				if rel.ID == artist.ID {
-					log.Printf("  ✓ Found relation for artist %d (%s) with %d locations\n", artist.ID, artist.Name, len(rel.DatesLocations))
-					for location, dates := range rel.DatesLocations {
-						if locCoords, ok := locationsMap[location]; ok {
-							log.Printf("    ✓ Location found: %s (lat=%.4f, lon=%.4f)\n", location, locCoords.Latitude, locCoords.Longitude)
-							concertsByLocation[location] = append(concertsByLocation[location], ConcertInfo{
-								Artist: artist.Name,
-								Dates:  dates,
-							})
-							matchedCount++
-						} else {
-							log.Printf("    ✗ Location NOT in map: %s\n", location)
-						}
-					}
-					break
-				}
-			}
+		offline = func() {
+			win.SetContent(container.NewCenter(widget.NewProgressBarInfinite()))
+			go openMapPage(win, artists, src, note, onBack, onArtist)
 		}
+	}
 
-		log.Printf("✓ Matched %d artist-location pairs\n", matchedCount)
+	fyne.Do(func() {
+		win.SetContent(mapErrorView(err, onBack, retry, offline))
+	})
+}
 
-		// on garde les lieux uniques
-		seen := make(map[string]bool)
-		for locName, loc := range locationsMap {
-			if !seen[locName] {
-				seen[locName] = true
-				concertLocations = append(concertLocations, loc)
-			}
+// construit la page carte (depuis un goroutine) ; offlineNote : bandeau si les
+// données ne viennent pas de l'api. Chaque étape est protégée : une panique
+// affiche la vue d'erreur avec l'étape en cause au lieu de fermer l'app
+func openMapPage(win *Window, artists []models.Artist, src *mapSources, offlineNote string, onBack func(), onArtist func(models.Artist)) {
+	fail := func(err error) {
+		showMapError(win, err, artists, onBack, onArtist)
+	}
+
+	// quitter la page (retour, fiche d'un artiste) arrête le géocodage progressif
+	left := make(chan struct{})
+	leave := sync.OnceFunc(func() { close(left) })
+	back := func() {
+		leave()
+		onBack()
+	}
+	openArtist := func(a models.Artist) {
+		leave()
+		onArtist(a)
+	}
+
+	data := &mapData{concertsByLocation: src.concertsByLocation}
+	concertsByLocation := data.concertsByLocation
+
+	// choix de ma position par clic sur la carte
+	pickingHome := false
+	pickHomeButton := widget.NewButton(T().PickHomeOnMap, nil)
+	pickHomeButton.OnTapped = func() {
+		pickingHome = !pickingHome
+		if pickingHome {
+			pickHomeButton.Importance = widget.HighImportance
+		} else {
+			pickHomeButton.Importance = widget.MediumImportance
 		}
-		log.Printf("✓ Final concert locations count: %d\n", len(concertLocations))
-
-		if len(concertLocations) == 0 {
-			log.Printf("✗ ERROR: No concert locations found!\n")
-			log.Printf("  - locationsMap size: %d\n", len(locationsMap))
-			log.Printf("  - concertsByLocation size: %d\n", len(concertsByLocation))
-			log.Printf("  - artists count: %d\n", len(artists))
-			log.Printf("  - relations count: %d\n", len(relations.Index))
-			fyne.CurrentApp().SendNotification(&fyne.Notification{
-				Title:   T().Error,
-				Content: "Aucun lieu de concert n'a pu être chargé",
-			})
+		pickHomeButton.Refresh()
+	}
+
+	var showHome func(lat, lon float64)
+	onMapTap := func(lat, lon float64) {
+		if !pickingHome {
 			return
 		}
-
-		// on peut générer la carte visuelle
-		fyne.Do(func() {
-			loadingLabel.SetText(fmt.Sprintf("Rendering %d locations...", len(concertLocations)))
-		})
-
-		log.Println("Creating map canvas...")
-		// création de la carte canvas
-		var mapCanvas fyne.CanvasObject
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("Erreur dans createMapCanvas: %v\n", r)
-				mapCanvas = canvas.NewText("Erreur lors de la création de la carte", ContrastColor(BgDarker))
+		name := fmt.Sprintf("%.4f, %.4f", lat, lon)
+		dialog.ShowConfirm(T().SetHome, fmt.Sprintf(T().HomeFromMapFmt, name), func(ok bool) {
+			if !ok {
+				return
 			}
-		}()
+			setHomeLocation(homeLocation{Name: name, Lat: lat, Lon: lon})
+			showHome(lat, lon)
+			pickHomeButton.OnTapped()
+		}, win.Window)
+	}
 
-		mapCanvas = createMapCanvasFromAPI(concertLocations, concertsByLocation)
-		log.Println("Map canvas created successfully")
+	// création de la carte canvas (cadrée sur les lieux déjà en cache)
+	log.Println("Creating map canvas...")
+	var lmap *locationMap
+	var mapCanvas fyne.CanvasObject
+	if err := runMapStage(T().StageMap, func() {
+		lmap = newLocationMap(cachedPlaceCoords(src.places), concertsByLocation, onMapTap)
+		mapCanvas = lmap.view.scroll
+		showHome = lmap.showHome
+	}); err != nil {
+		fail(err)
+		return
+	}
+	log.Println("Map canvas created successfully")
+
+	// liste des lieux liée aux marqueurs, avec recherche commune,
+	// et panneau de détails ancré à droite de la carte
+	log.Println("Creating locations list...")
+	var selection *locationSelection
+	var details *markerDetails
+	if err := runMapStage(T().StageList, func() {
+		selection = newLocationSelection(lmap, nil, concertsByLocation)
+		details = newMarkerDetails(concertsByLocation, artists, openArtist)
+		selection.onSelect = details.show
+		lmap.onMarker = func(location string) {
+			selection.selectLocation(location, true)
+		}
+	}); err != nil {
+		fail(err)
+		return
+	}
+	log.Println("Locations list created successfully")
+
+	// pays coloriés par nombre de concerts / d'artistes, recherche de lieu
+	var choropleth *choroplethLayer
+	var modeSelect *widget.Select
+	if err := runMapStage(T().StageLayers, func() {
+		choropleth = newChoroplethLayer(lmap.view, data)
+		mapCanvas = choropleth.withLegend(mapCanvas)
+		modeSelect = widget.NewSelect([]string{T().ChoroplethOff, T().ChoroplethConcerts, T().ChoroplethArtists}, nil)
+		modeSelect.OnChanged = func(string) {
+			choropleth.setMode(choroplethMode(modeSelect.SelectedIndex()))
+		}
+		modeSelect.SetSelectedIndex(0)
+
+		search := newMapSearch(lmap, selection)
+		mapCanvas = search.withSuggestions(mapCanvas)
+		mapCanvas = container.NewBorder(search.bar(), nil, nil, details.panel, mapCanvas)
+	}); err != nil {
+		fail(err)
+		return
+	}
 
-		// on prépare la liste des lieux
-		fyne.Do(func() {
-			loadingLabel.SetText("Loading locations list...")
-		})
-		time.Sleep(300 * time.Millisecond)
-
-		log.Println("Creating locations list...")
-		// on génère la liste des lieux
-		var locationsList fyne.CanvasObject
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("Erreur dans createLocationsList: %v\n", r)
-				locationsList = canvas.NewText("Erreur lors de la création de la liste", ContrastColor(BgDarker))
+	// petit résumé du nombre de lieux
+	infoLabel := widget.NewLabel(fmt.Sprintf("%d "+T().Location, 0))
+	infoLabel.Alignment = fyne.TextAlignCenter
+	infoLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	// lieu géocodé : marqueur, entrée de liste et données d'export
+	addPlace := func(place string, coords *models.LocationCoords) error {
+		// déjà placé (relance arrivée après coup) : rien à ajouter
+		for _, loc := range data.locations {
+			if loc.Lieux == place {
+				return nil
 			}
-		}()
-		locationsList = createLocationsListFromAPI(concertLocations, concertsByLocation)
-		log.Println("Locations list created successfully")
-		scrollLocations := container.NewScroll(locationsList)
-		scrollLocations.SetMinSize(fyne.NewSize(400, 600))
+		}
+		return runMapStage(T().StageMarkers, func() {
+			loc := *coords
+			loc.Lieux = place
+			data.locations = append(data.locations, &loc)
+			lmap.addLocation(&loc)
+			selection.add(&loc)
+			infoLabel.SetText(fmt.Sprintf("%d "+T().Location, len(data.locations)))
+		})
+	}
 
-		// petit résumé du nombre de lieux
-		infoLabel := widget.NewLabel(fmt.Sprintf("%d "+T().Location, len(concertLocations)))
-		infoLabel.Alignment = fyne.TextAlignCenter
-		infoLabel.TextStyle = fyne.TextStyle{Bold: true}
+	// progression du géocodage, lieux en échec à relancer
+	progress := newMapProgress(len(src.places), func(place string, done func(ok bool)) {
+		go func() {
+			coords := geocodeLocationFast(place)
+			fyne.Do(func() {
+				ok := coords != nil && coords.Latitude != 0 && coords.Longitude != 0
+				if ok && addPlace(place, coords) == nil {
+					choropleth.refresh()
+				} else {
+					ok = false
+				}
+				done(ok)
+			})
+		}()
+	})
 
+	var finalContent fyne.CanvasObject
+	if err := runMapStage(T().StageLayout, func() {
 		// titre de la page
 		title := widget.NewLabel(T().ConcertLocations)
 		title.TextStyle = fyne.TextStyle{Bold: true}
 		title.Alignment = fyne.TextAlignCenter
 
 		// carte + liste côte à côte (70% carte, 30% liste)
-		contentDisplay := container.NewHSplit(mapCanvas, scrollLocations)
+		contentDisplay := container.NewHSplit(mapCanvas, selection.content())
 		contentDisplay.Offset = 0.7 // 70% pour la carte, 30% pour la liste
 
+		backButton := widget.NewButton(T().Back, back)
+		backButton.Importance = widget.HighImportance
+
+		// bouton d'export de la carte en PNG
+		exportButton := widget.NewButton(T().ExportImage, func() {
+			showMapExportDialog(win.Window, data.snapshot())
+		})
+
+		// export des lieux et tournées (GeoJSON, KML, GPX)
+		exportDataButton := widget.NewButton(T().ExportData, func() {
+			showGeoExportDialog(win.Window, data.snapshot())
+		})
+
+		// fond vectoriel seul (hors ligne, assorti au thème) ou tuiles OSM
+		vectorCheck := widget.NewCheck(T().VectorBasemap, func(on bool) {
+			setVectorBasemap(on)
+			lmap.view.setVectorOnly(on)
+		})
+		vectorCheck.SetChecked(useVectorBasemap())
+
+		// tuiles d'origine ou retouchées aux couleurs du thème
+		darkTilesCheck := widget.NewCheck(T().DarkTiles, func(on bool) {
+			style := tileStyleOriginal
+			if on {
+				style = tileStyleDark
+			}
+			if style == currentTileStyle() {
+				return
+			}
+			setTileStyle(style)
+			lmap.view.reloadTiles()
+		})
+		darkTilesCheck.SetChecked(currentTileStyle() == tileStyleDark)
+
+		header := container.NewVBox(container.NewHBox(backButton, exportButton, exportDataButton, pickHomeButton, vectorCheck, darkTilesCheck, modeSelect), title)
+		if offlineNote != "" {
+			note := widget.NewLabel(offlineNote)
+			note.Alignment = fyne.TextAlignCenter
+			note.Importance = widget.WarningImportance
+			header.Add(note)
+		}
+		header.Add(infoLabel)
+		header.Add(progress.box)
+
 		// border final
-		finalContent := container.NewBorder(
-			container.NewVBox(backButton, title, infoLabel),
-			nil, nil, nil,
-			contentDisplay,
-		)
-
-		// Mettre à jour le contenu de la window depuis le thread UI
-		log.Println("Affichage de la carte avec", len(concertLocations), "lieux")
+		finalContent = container.NewBorder(header, nil, nil, nil, contentDisplay)
+	}); err != nil {
+		fail(err)
+		return
+	}
+
+	// Mettre à jour le contenu de la window depuis le thread UI
+	log.Println("Affichage de la carte,", len(src.places), "lieux à géocoder")
+	fyne.Do(func() {
+		win.SetContent(finalContent)
+	})
+
+	// les marqueurs arrivent au fil du géocodage
+	geocodePlaces(left, src.places, func(place string, coords *models.LocationCoords) {
 		fyne.Do(func() {
-			win.SetContent(finalContent)
+			ok := coords != nil && addPlace(place, coords) == nil
+			progress.step(place, ok)
 		})
-	}()
+	})
+	select {
+	case <-left:
+	default:
+		fyne.Do(choropleth.refresh)
+	}
+}
+
+// données de la carte : lieux géocodés + concerts par lieu
+type mapData struct {
+	locations          []*models.LocationCoords
+	concertsByLocation map[string][]ConcertInfo
+}
+
+// charge relations + locations, géocode les lieux et associe les concerts
+// (bloquant, ne touche pas à l'UI : utilisable sans fenêtre)
+func loadMapData(artists []models.Artist, onStatus func(string)) (*mapData, error) {
+	status := func(s string) {
+		if onStatus != nil {
+			onStatus(s)
+		}
+	}
+
+	src, err := fetchMapSources(artists)
+	if err != nil {
+		return nil, err
+	}
+
+	status(T().Loading)
+
+	// géocodage (un par un, file Nominatim)
+	locationsMap := make(map[string]*models.LocationCoords)
+	done := 0
+	geocodePlaces(nil, src.places, func(place string, coords *models.LocationCoords) {
+		done++
+		if coords != nil {
+			locationsMap[place] = coords
+		}
+		status(fmt.Sprintf(T().GeocodedFmt, done, len(src.places)))
+	})
+	log.Printf("✓ Locations map built: %d unique places\n", len(locationsMap))
+
+	// on ne garde que les concerts des lieux géocodés
+	data := &mapData{concertsByLocation: make(map[string][]ConcertInfo)}
+	for _, place := range src.places {
+		if loc, ok := locationsMap[place]; ok {
+			data.locations = append(data.locations, loc)
+		}
+	}
+	for location, concerts := range src.concertsByLocation {
+		if _, ok := locationsMap[location]; ok {
+			data.concertsByLocation[location] = concerts
+		} else {
+			log.Printf("    ✗ Location NOT in map: %s\n", location)
+		}
+	}
+	log.Printf("✓ Final concert locations count: %d\n", len(data.locations))
+
+	if len(data.locations) == 0 {
+		log.Printf("✗ ERROR: No concert locations found!\n")
+		log.Printf("  - places count: %d\n", len(src.places))
+		log.Printf("  - artists count: %d\n", len(artists))
+		return nil, fmt.Errorf("Aucun lieu de concert n'a pu être chargé")
+	}
+	return data, nil
+}
+
+// copie des données (export pendant que la carte se remplit)
+func (d *mapData) snapshot() *mapData {
+	c := &mapData{
+		locations:          append([]*models.LocationCoords(nil), d.locations...),
+		concertsByLocation: make(map[string][]ConcertInfo, len(d.concertsByLocation)),
+	}
+	for k, v := range d.concertsByLocation {
+		c.concertsByLocation[k] = v
+	}
+	return c
 }
 
 // compat
@@ -261,9 +411,23 @@ func geocodeLocationFast(location string) *models.LocationCoords {
 	// replace underscore with space for better geocoding
 	query := strings.ReplaceAll(normalizedLocation, "_", " ")
 
+	coords := nominatimSearch(location, query)
+	if coords == nil {
+		return nil
+	}
+
+	// Cache the result
+	models.CacheCoords(location, coords)
+
+	return coords
+}
+
+// requête Nominatim (file partagée, 3 essais) sans passer par le cache ;
+// label : nom du lieu dans les logs et dans Lieux
+func nominatimSearch(label, query string) *models.LocationCoords {
 	// create the Nominatim API URL
 	url := fmt.Sprintf("https://nominatim.openstreetmap.org/search?q=%s&format=json&limit=1",
-		strings.ReplaceAll(query, " ", "+"))
+		neturl.QueryEscape(query))
 
 	// Retry logic with exponential backoff
 	maxAttempts := 3
@@ -271,6 +435,9 @@ func geocodeLocationFast(location string) *models.LocationCoords {
 	var err error
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		// tour dans la file Nominatim avant de lancer le délai de la requête
+		release := nominatimSlot()
+
 		// Create request with context timeout (10 seconds per attempt)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		req, _ := http.NewRequest("GET", url, nil)
@@ -280,40 +447,41 @@ func geocodeLocationFast(location string) *models.LocationCoords {
 		client := &http.Client{Timeout: 10 * time.Second}
 		resp, err = client.Do(req)
 		cancel()
+		release()
 
 		if err == nil && resp.StatusCode == 200 {
 			break // Success
 		}
 
 		if err != nil {
-			log.Printf("[GEOCODE FAIL] %s attempt %d/%d: %v\n", location, attempt, maxAttempts, err)
+			log.Printf("[GEOCODE FAIL] %s attempt %d/%d: %v\n", label, attempt, maxAttempts, err)
 		} else {
-			log.Printf("[GEOCODE HTTP %d] %s attempt %d/%d\n", resp.StatusCode, location, attempt, maxAttempts)
+			log.Printf("[GEOCODE HTTP %d] %s attempt %d/%d\n", resp.StatusCode, label, attempt, maxAttempts)
 			resp.Body.Close()
 		}
 
 		// Exponential backoff: 500ms, 1s, 2s
 		if attempt < maxAttempts {
 			backoffDuration := time.Duration(500*int(math.Pow(2, float64(attempt-1)))) * time.Millisecond
-			log.Printf("[GEOCODE RETRY] %s in %v\n", location, backoffDuration)
+			log.Printf("[GEOCODE RETRY] %s in %v\n", label, backoffDuration)
 			time.Sleep(backoffDuration)
 		}
 	}
 
 	if err != nil || resp == nil || resp.StatusCode != 200 {
-		log.Printf("[GEOCODE FAILED] %s - all retries exhausted\n", location)
+		log.Printf("[GEOCODE FAILED] %s - all retries exhausted\n", label)
 		return nil
 	}
 	defer resp.Body.Close()
 
 	var results []map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		log.Printf("[GEOCODE DECODE ERR] %s: %v\n", location, err)
+		log.Printf("[GEOCODE DECODE ERR] %s: %v\n", label, err)
 		return nil
 	}
 
 	if len(results) == 0 {
-		log.Printf("[GEOCODE NO RESULTS] %s\n", location)
+		log.Printf("[GEOCODE NO RESULTS] %s\n", label)
 		return nil
 	}
 
@@ -321,19 +489,11 @@ func geocodeLocationFast(location string) *models.LocationCoords {
 	lat, _ := strconv.ParseFloat(fmt.Sprintf("%v", result["lat"]), 64)
 	lon, _ := strconv.ParseFloat(fmt.Sprintf("%v", result["lon"]), 64)
 
-	coords := &models.LocationCoords{
-		Lieux:     location,
+	return &models.LocationCoords{
+		Lieux:     label,
 		Latitude:  lat,
 		Longitude: lon,
 	}
-
-	// Cache the result
-	models.CacheCoords(location, coords)
-
-	// respecter le rate limit de Nominatim (1 req/sec minimum)
-	time.Sleep(800 * time.Millisecond)
-
-	return coords
 }
 
 func getApproxCoordinates(location string) *models.LocationCoords {
@@ -411,346 +571,105 @@ func normalizeLocation(location string) string {
 	return location
 }
 
-// dessine carte
-func createMapCanvasFromAPI(locations []*models.LocationCoords, concertsByLocation map[string][]ConcertInfo) fyne.CanvasObject {
-	log.Printf("createMapCanvasFromAPI called with %d locations\n", len(locations))
-	if len(locations) == 0 {
-		return canvas.NewText(T().NoLocations, ContrastColor(BgDarker))
-	}
-
-	// Implementation using OpenStreetMap tiles (slippy map)
-	// tile size is 256x256
-	const tileSize = 256
-
-	log.Println("Determining bounding box...")
-	// determine bounding box
-	minLat := locations[0].Latitude
-	maxLat := locations[0].Latitude
-	minLon := locations[0].Longitude
-	maxLon := locations[0].Longitude
-	for _, loc := range locations {
-		if loc.Latitude < minLat {
-			minLat = loc.Latitude
-		}
-		if loc.Latitude > maxLat {
-			maxLat = loc.Latitude
-		}
-		if loc.Longitude < minLon {
-			minLon = loc.Longitude
-		}
-		if loc.Longitude > maxLon {
-			maxLon = loc.Longitude
-		}
-	}
-
-	// padding
-	latRange := maxLat - minLat
-	lonRange := maxLon - minLon
-
-	if latRange < 0.1 {
-		latRange = 0.1
-	}
-	if lonRange < 0.1 {
-		lonRange = 0.1
-	}
-	padLat := latRange * 0.1
-	padLon := lonRange * 0.1
-	minLat -= padLat
-	maxLat += padLat
-	minLon -= padLon
-	maxLon += padLon
-
-	latRange = maxLat - minLat
-	lonRange = maxLon - minLon
-
-	// target canvas display size
-	mapWidth := float32(1920)
-	mapHeight := float32(1080)
-
-	// choose zoom to roughly fit bounding box into mapWidth/mapHeight
-	// test zooms from 1..6 and pick the one where tile pixel span is >= map size
-	// (6 is reasonable for worldwide view; 12+ would load millions of tiles)
-	chooseZoom := func() int {
-		for z := 2; z >= 1; z-- {
-			tx1, ty1 := latLonToTileXY(maxLat, minLon, z)
-			tx2, ty2 := latLonToTileXY(minLat, maxLon, z)
-			dx := math.Abs(tx2-tx1) * float64(tileSize)
-			dy := math.Abs(ty2-ty1) * float64(tileSize)
-			if dx >= float64(mapWidth) || dy >= float64(mapHeight) {
-				return z
-			}
-		}
-		return 2
-	}
-
-	zoom := chooseZoom()
-	maxTiles := 150
-	log.Printf("Chosen zoom level: %d\n", zoom)
-
-	// compute tile ranges
-	recompute := func(z int) (int, int, int, int, int, int) {
-		tx1, ty1 := latLonToTileXY(maxLat, minLon, z)
-		tx2, ty2 := latLonToTileXY(minLat, maxLon, z)
-		xMin := int(math.Floor(math.Min(tx1, tx2)))
-		xMax := int(math.Ceil(math.Max(tx1, tx2)))
-		yMin := int(math.Floor(math.Min(ty1, ty2)))
-		yMax := int(math.Ceil(math.Max(ty1, ty2)))
-		return xMin, xMax, yMin, yMax, xMax - xMin + 1, yMax - yMin + 1
-	}
+// marqueur d'un lieu et sa position
+type mapMarker struct {
+	lat, lon float64
+	widget   *locationMarker
+}
 
-	xMin, xMax, yMin, yMax, tilesX, tilesY := recompute(zoom)
-	totalTiles := tilesX * tilesY
+// carte des concerts avec ses marqueurs indexés par lieu
+type locationMap struct {
+	view     *mapView
+	markers  map[string]*mapMarker
+	preview  *markerPreview
+	concerts map[string][]ConcertInfo
+	showHome func(lat, lon float64) // place le marqueur "ma position"
+	onMarker func(location string)  // clic sur un marqueur
+}
 
-	// Clamp tile coordinates to valid range for the zoom level
-	// At zoom N, tiles go from 0 to 2^N - 1
-	maxTileCoord := int(math.Pow(2, float64(zoom))) - 1
-	if xMin < 0 {
-		xMin = 0
-	}
-	if xMax > maxTileCoord {
-		xMax = maxTileCoord
-	}
-	if yMin < 0 {
-		yMin = 0
+// carte vide cadrée sur frame ; les marqueurs sont ajoutés au fil du géocodage
+// onTap reçoit la position géographique d'un clic
+func newLocationMap(frame []*models.LocationCoords, concertsByLocation map[string][]ConcertInfo, onTap func(lat, lon float64)) *locationMap {
+	log.Printf("newLocationMap called with %d framing points\n", len(frame))
+
+	// carte OSM (slippy map) ajustée aux lieux : zoom 2 max, 150 tuiles max
+	view := newMapView(frame, fyne.NewSize(1920, 1080), 2, 150, onTap)
+	lm := &locationMap{view: view, markers: make(map[string]*mapMarker), concerts: concertsByLocation}
+	lm.preview = newMarkerPreview(view)
+
+	// marqueur "ma position"
+	homeMarker := canvas.NewCircle(AccentCyan)
+	homeMarker.StrokeColor = TextWhite
+	homeMarker.StrokeWidth = 2
+	homeMarker.Hide()
+	view.markers.Add(homeMarker)
+
+	lm.showHome = func(lat, lon float64) {
+		view.place(homeMarker, lat, lon, fyne.NewSize(16, 16))
+		homeMarker.Show()
+		homeMarker.Refresh()
 	}
-	if yMax > maxTileCoord {
-		yMax = maxTileCoord
+	if h := getHomeLocation(); h != nil {
+		lm.showHome(h.Lat, h.Lon)
 	}
-	tilesX = xMax - xMin + 1
-	tilesY = yMax - yMin + 1
-	totalTiles = tilesX * tilesY
-
-	if totalTiles > maxTiles {
-		for z := zoom - 1; z >= 1; z-- {
-			xMin, xMax, yMin, yMax, tilesX, tilesY = recompute(z)
-
-			// Clamp again after recomputing
-			maxTileCoord = int(math.Pow(2, float64(z))) - 1
-			if xMin < 0 {
-				xMin = 0
-			}
-			if xMax > maxTileCoord {
-				xMax = maxTileCoord
-			}
-			if yMin < 0 {
-				yMin = 0
-			}
-			if yMax > maxTileCoord {
-				yMax = maxTileCoord
-			}
-			tilesX = xMax - xMin + 1
-			tilesY = yMax - yMin + 1
-			totalTiles = tilesX * tilesY
-
-			if totalTiles <= maxTiles {
-				log.Printf("Tile grid too large at zoom %d, clamped to zoom %d (%d tiles)\n", zoom, z, totalTiles)
-				zoom = z
-				break
-			}
-		}
-	}
-
-	log.Printf("Tile grid: %dx%d (total %d tiles)\n", tilesX, tilesY, totalTiles)
-
-	totalWidth := float32(tilesX * tileSize)
-	totalHeight := float32(tilesY * tileSize)
 
-	// container for tiles and overlays - use WithoutLayout to stack layers
-	mapContainer := container.NewWithoutLayout()
-
-	// a dedicated container for absolute placement
-	tileContainer := container.NewWithoutLayout()
+	log.Println("Map canvas completed (loading tiles in background)")
+	return lm
+}
 
-	// Create placeholders immediately so map shows instantly
-	log.Println("Creating placeholder grid...")
-	for x := xMin; x <= xMax; x++ {
-		for y := yMin; y <= yMax; y++ {
-			rect := canvas.NewRectangle(ContrastColor(BgDarker))
-			rect.Move(fyne.NewPos(float32((x-xMin)*tileSize), float32((y-yMin)*tileSize)))
-			rect.Resize(fyne.NewSize(tileSize, tileSize))
-			tileContainer.Add(rect)
-		}
+// ajoute le marqueur d'un lieu : aperçu au survol, détails au clic
+func (lm *locationMap) addLocation(loc *models.LocationCoords) {
+	location := loc.Lieux
+	if _, ok := lm.markers[location]; ok {
+		return
 	}
-
-	tileContainer.Resize(fyne.NewSize(totalWidth, totalHeight))
-	mapContainer.Add(tileContainer)
-
-	// Separate container for markers (on top of tiles)
-	markerContainer := container.NewWithoutLayout()
-
-	// Container for tooltips (on top of everything)
-	tooltipContainer := container.NewWithoutLayout()
-
-	// Add markers with hover tooltips
-	for _, loc := range locations {
-		tx, ty := latLonToTileXY(loc.Latitude, loc.Longitude, zoom)
-		px := float32((tx - float64(xMin)) * float64(tileSize))
-		py := float32((ty - float64(yMin)) * float64(tileSize))
-
-		marker := canvas.NewCircle(AccentPink)
-		marker.StrokeWidth = 1
-		marker.StrokeColor = AccentCyan
-		marker.Resize(fyne.NewSize(12, 12))
-		marker.Move(fyne.NewPos(px-6, py-6))
-		markerContainer.Add(marker)
-
-		// Create tooltip text for this location
-		locationName := strings.ReplaceAll(loc.Lieux, "_", " ")
-		locationName = strings.ReplaceAll(locationName, "-", ", ")
-
-		// Build tooltip content as vertical container
-		tooltipContent := container.NewVBox()
-
-		// Location name
-		locationText := canvas.NewText(locationName, color.Black)
-		locationText.TextSize = 12
-		locationText.TextStyle = fyne.TextStyle{Bold: true}
-		tooltipContent.Add(locationText)
-		tooltipContent.Add(widget.NewSeparator())
-
-		// Get concerts for this location
-		if concerts, ok := concertsByLocation[loc.Lieux]; ok {
-			for _, concert := range concerts {
-				artistText := canvas.NewText(concert.Artist, color.Black)
-				artistText.TextSize = 11
-				artistText.TextStyle = fyne.TextStyle{Bold: true}
-				tooltipContent.Add(artistText)
-
-				for _, date := range concert.Dates {
-					dateText := canvas.NewText(date, color.Black)
-					dateText.TextSize = 10
-					tooltipContent.Add(dateText)
-				}
-			}
+	marker := newLocationMarker()
+	marker.onHover = func(in bool) {
+		if in {
+			lm.preview.show(location, lm.concerts[location], loc.Latitude, loc.Longitude)
 		} else {
-			noConcertText := canvas.NewText("(Pas de concerts)", color.Black)
-			noConcertText.TextSize = 10
-			tooltipContent.Add(noConcertText)
+			lm.preview.hide()
 		}
-
-		// Create tooltip box with white background and padding
-		tooltip := canvas.NewRectangle(color.White)
-		tooltip.StrokeColor = color.Gray{Y: 200}
-		tooltip.StrokeWidth = 1
-
-		// Add padding around the text
-		paddedContent := container.NewPadded(tooltipContent)
-
-		// Container with background and content
-		bgContainer := container.NewStack(tooltip, paddedContent)
-		bgContainer.Resize(fyne.NewSize(280, 200))
-
-		tooltipBox := bgContainer
-		tooltipBox.Move(fyne.NewPos(px+15, py-10))
-		tooltipBox.Hide()
-
-		tooltipContainer.Add(tooltipBox)
-
-		// Create custom tappable for hover detection
-		tappable := widget.NewButton("", nil)
-		tappable.Importance = widget.LowImportance
-		tappable.Resize(fyne.NewSize(24, 24))
-		tappable.Move(fyne.NewPos(px-12, py-12))
-
-		// Store reference for closure
-		currentTooltip := tooltipBox
-
-		tappable.OnTapped = func() {
-			if currentTooltip.Visible() {
-				currentTooltip.Hide()
-			} else {
-				currentTooltip.Show()
-			}
+	}
+	marker.onTapped = func() {
+		lm.preview.hide()
+		if lm.onMarker != nil {
+			lm.onMarker(location)
 		}
-
-		markerContainer.Add(tappable)
 	}
+	lm.view.place(marker, loc.Latitude, loc.Longitude, markerHitSize)
+	lm.view.markers.Add(marker)
 
-	markerContainer.Resize(fyne.NewSize(totalWidth, totalHeight))
-	mapContainer.Add(markerContainer)
-
-	tooltipContainer.Resize(fyne.NewSize(totalWidth, totalHeight))
-	mapContainer.Add(tooltipContainer)
-
-	// Set the map container to the full tile grid size
-	mapContainer.Resize(fyne.NewSize(totalWidth, totalHeight))
+	lm.markers[location] = &mapMarker{lat: loc.Latitude, lon: loc.Longitude, widget: marker}
+}
 
-	scroll := container.NewScroll(mapContainer)
+// met en avant (ou non) le marqueur d'un lieu
+func (lm *locationMap) highlight(location string, on bool) {
+	if m, ok := lm.markers[location]; ok {
+		m.widget.setHighlighted(on)
+	}
+}
 
-	// Now fetch tiles in background and update placeholders when ready
-	go func() {
-		var wg sync.WaitGroup
-		client := &http.Client{Timeout: 5 * time.Second}
-		tilesData := make(map[string][]byte)
-		var tilesMu sync.Mutex
-
-		maxConcurrent := 4
-		semaphore := make(chan struct{}, maxConcurrent)
-
-		tilesDownloaded := 0
-		for x := xMin; x <= xMax; x++ {
-			for y := yMin; y <= yMax; y++ {
-				wg.Add(1)
-				go func(tx, ty int) {
-					defer wg.Done()
-					semaphore <- struct{}{}        // acquire
-					defer func() { <-semaphore }() // release
-
-					time.Sleep(30 * time.Millisecond)
-
-					path := tileCachePath(zoom, tx, ty)
-					if b, err := os.ReadFile(path); err == nil && len(b) > 0 {
-						if looksLikePNG(b) {
-							tilesMu.Lock()
-							tilesData[fmt.Sprintf("%d_%d", tx, ty)] = b
-							tilesMu.Unlock()
-
-							// Update UI immediately
-							fyne.Do(func() {
-								res := fyne.NewStaticResource(fmt.Sprintf("%d_%d_%d.png", zoom, tx, ty), b)
-								img := canvas.NewImageFromResource(res)
-								img.FillMode = canvas.ImageFillContain
-								img.Move(fyne.NewPos(float32((tx-xMin)*tileSize), float32((ty-yMin)*tileSize)))
-								img.Resize(fyne.NewSize(tileSize, tileSize))
-								tileContainer.Add(img)
-								tileContainer.Refresh()
-							})
-							return
-						}
-						_ = os.Remove(path)
-					}
-
-					resBytes := getTileBytes(client, zoom, tx, ty)
-					if len(resBytes) > 0 {
-						_ = os.WriteFile(path, resBytes, 0o644)
-						tilesMu.Lock()
-						tilesData[fmt.Sprintf("%d_%d", tx, ty)] = resBytes
-						tilesDownloaded++
-						if tilesDownloaded%10 == 0 || tilesDownloaded == tilesX*tilesY {
-							log.Printf("Downloading tiles: %d/%d\n", tilesDownloaded, tilesX*tilesY)
-						}
-						tilesMu.Unlock()
-
-						// Update UI immediately
-						fyne.Do(func() {
-							res := fyne.NewStaticResource(fmt.Sprintf("%d_%d_%d.png", zoom, tx, ty), resBytes)
-							img := canvas.NewImageFromResource(res)
-							img.FillMode = canvas.ImageFillContain
-							img.Move(fyne.NewPos(float32((tx-xMin)*tileSize), float32((ty-yMin)*tileSize)))
-							img.Resize(fyne.NewSize(tileSize, tileSize))
-							tileContainer.Add(img)
-							tileContainer.Refresh()
-						})
-					}
-				}(x, y)
-			}
-		}
-		wg.Wait()
-		log.Printf("Finished downloading %d tiles (expected %d)\n", len(tilesData), tilesX*tilesY)
-	}()
+// affiche ou masque un marqueur (recherche)
+func (lm *locationMap) setVisible(location string, visible bool) {
+	m, ok := lm.markers[location]
+	if !ok {
+		return
+	}
+	if visible {
+		m.widget.Show()
+	} else {
+		m.widget.Hide()
+		lm.preview.hide()
+	}
+}
 
-	log.Println("Map canvas completed (loading tiles in background)")
-	return scroll
+func (lm *locationMap) markerFor(location string) (*mapMarker, bool) {
+	if lm == nil {
+		return nil, false
+	}
+	m, ok := lm.markers[location]
+	return m, ok
 }
 
 // cache tiles on disk under $TMP/groupie-tiles
@@ -834,61 +753,3 @@ func latLonToTileXY(lat, lon float64, zoom int) (float64, float64) {
 	y := (1.0 - math.Log(math.Tan(latRad)+1.0/math.Cos(latRad))/math.Pi) / 2.0 * n
 	return x, y
 }
-
-// index lieu
-func indexOfLocationFromAPI(locations []*models.LocationCoords, target *models.LocationCoords) int {
-	for i, loc := range locations {
-		if loc.Lieux == target.Lieux {
-			return i
-		}
-	}
-	return -1
-}
-
-// liste lieux
-func createLocationsListFromAPI(locations []*models.LocationCoords, concertsByLocation map[string][]ConcertInfo) *fyne.Container {
-	var items []fyne.CanvasObject
-
-	// titre de la liste
-	titleLabel := widget.NewLabel(T().LocationsListTitle)
-	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
-	items = append(items, titleLabel)
-	items = append(items, widget.NewSeparator())
-
-	for _, loc := range locations {
-		// conteneur pour chaque lieu
-		locationName := strings.ReplaceAll(loc.Lieux, "_", " ")
-		locationName = strings.ReplaceAll(locationName, "-", ", ")
-
-		// affiche les coords fournies par l'api
-		coordsText := fmt.Sprintf("(%.4f, %.4f)", loc.Latitude, loc.Longitude)
-		locLabel := widget.NewLabel("• " + locationName + " " + coordsText)
-		locLabel.TextStyle = fyne.TextStyle{Bold: true}
-
-		items = append(items, locLabel)
-
-		// liste les concerts associés
-		if concerts, ok := concertsByLocation[loc.Lieux]; ok {
-			for _, concert := range concerts {
-				artistLabel := widget.NewLabel("  ♫ " + concert.Artist)
-				items = append(items, artistLabel)
-
-				// on affiche quelques dates
-				maxDates := 3
-				for i, date := range concert.Dates {
-					if i >= maxDates {
-						remaining := len(concert.Dates) - maxDates
-						items = append(items, widget.NewLabel(fmt.Sprintf(T().MoreDatesFmt, remaining)))
-						break
-					}
-					dateLabel := widget.NewLabel("      • " + date)
-					items = append(items, dateLabel)
-				}
-			}
-		}
-
-		items = append(items, widget.NewSeparator())
-	}
-
-	return container.NewVBox(items...)
-}
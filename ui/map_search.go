@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// préférence : derniers lieux rejoints depuis la recherche (clé de lieu ou texte libre)
+const prefRecentJumps = "map.recent_jumps"
+
+// taille de l'historique et nombre max de suggestions
+const (
+	maxRecentJumps    = 8
+	maxMapSuggestions = 8
+)
+
+// recherche de lieu au-dessus de la carte : suggestions parmi les lieux de concerts,
+// géocodage pour les autres lieux, vol animé jusqu'au résultat
+type mapSearch struct {
+	lmap      *locationMap
+	selection *locationSelection
+
+	entry       *widget.Entry
+	suggestions *fyne.Container // posé par-dessus la carte
+	items       *fyne.Container
+	pin         *canvas.Circle // lieu trouvé par le géocodeur
+}
+
+func newMapSearch(lmap *locationMap, selection *locationSelection) *mapSearch {
+	s := &mapSearch{lmap: lmap, selection: selection}
+
+	s.entry = widget.NewEntry()
+	s.entry.SetPlaceHolder(T().SearchPlace)
+	s.entry.OnChanged = s.suggest
+	s.entry.OnSubmitted = s.submit
+
+	s.items = container.NewVBox()
+	bg := canvas.NewRectangle(CardBg)
+	bg.StrokeColor = AccentCyan
+	bg.StrokeWidth = 1
+	bg.CornerRadius = 4
+	sizer := canvas.NewRectangle(CardBg)
+	sizer.SetMinSize(fyne.NewSize(320, 0))
+	s.suggestions = container.NewStack(sizer, bg, container.NewPadded(s.items))
+	s.suggestions.Hide()
+
+	s.pin = canvas.NewCircle(AccentCyan)
+	s.pin.StrokeColor = TextWhite
+	s.pin.StrokeWidth = 2
+	s.pin.Hide()
+	lmap.view.markers.Add(s.pin)
+	return s
+}
+
+// barre de recherche : champ + bouton historique
+func (s *mapSearch) bar() fyne.CanvasObject {
+	recent := widget.NewButton("🕘", func() {
+		if s.suggestions.Visible() {
+			s.suggestions.Hide()
+			return
+		}
+		s.showRecent()
+	})
+	recent.Importance = widget.LowImportance
+	return container.NewBorder(nil, nil, nil, recent, s.entry)
+}
+
+// carte avec les suggestions en haut à gauche
+func (s *mapSearch) withSuggestions(mapObj fyne.CanvasObject) fyne.CanvasObject {
+	return container.NewStack(mapObj, container.NewVBox(
+		container.NewHBox(container.NewPadded(s.suggestions), layout.NewSpacer()),
+		layout.NewSpacer(),
+	))
+}
+
+// lieux connus correspondant au texte (début de nom d'abord)
+func (s *mapSearch) matches(query string) []*models.LocationCoords {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var prefix, inside []*models.LocationCoords
+	for _, loc := range s.selection.all {
+		name := strings.ToLower(displayLocationName(loc.Lieux))
+		switch {
+		case strings.HasPrefix(name, query):
+			prefix = append(prefix, loc)
+		case strings.Contains(name, query):
+			inside = append(inside, loc)
+		}
+	}
+	found := append(prefix, inside...)
+	if len(found) > maxMapSuggestions {
+		found = found[:maxMapSuggestions]
+	}
+	return found
+}
+
+// suggestions pendant la saisie ; champ vide : historique
+func (s *mapSearch) suggest(text string) {
+	query := strings.TrimSpace(text)
+	if query == "" {
+		s.showRecent()
+		return
+	}
+
+	s.items.RemoveAll()
+	for _, loc := range s.matches(query) {
+		location := loc.Lieux
+		s.items.Add(s.item("📍 "+displayLocationName(location), func() { s.jumpTo(location) }))
+	}
+	// lieu quelconque via le géocodeur
+	s.items.Add(s.item(fmt.Sprintf(T().SearchPlaceFmt, query), func() { s.geocode(query) }))
+	s.suggestions.Show()
+	s.suggestions.Refresh()
+}
+
+// derniers lieux rejoints
+func (s *mapSearch) showRecent() {
+	s.items.RemoveAll()
+	recent := recentJumps()
+	if len(recent) == 0 {
+		s.items.Add(widget.NewLabel(T().NoRecentJumps))
+	} else {
+		title := widget.NewLabel(T().RecentJumps)
+		title.TextStyle = fyne.TextStyle{Bold: true}
+		s.items.Add(title)
+	}
+	for _, r := range recent {
+		query := r
+		if _, ok := s.lmap.markerFor(query); ok {
+			s.items.Add(s.item("🕘 "+displayLocationName(query), func() { s.jumpTo(query) }))
+		} else {
+			s.items.Add(s.item("🕘 "+query, func() { s.geocode(query) }))
+		}
+	}
+	s.suggestions.Show()
+	s.suggestions.Refresh()
+}
+
+func (s *mapSearch) item(label string, onTapped func()) fyne.CanvasObject {
+	btn := widget.NewButton(label, onTapped)
+	btn.Alignment = widget.ButtonAlignLeading
+	btn.Importance = widget.LowImportance
+	return btn
+}
+
+// Entrée : premier lieu connu, sinon géocodeur
+func (s *mapSearch) submit(text string) {
+	query := strings.TrimSpace(text)
+	if query == "" {
+		return
+	}
+	if found := s.matches(query); len(found) > 0 {
+		s.jumpTo(found[0].Lieux)
+		return
+	}
+	s.geocode(query)
+}
+
+// vol jusqu'à un lieu de concert puis ouverture de ses détails
+func (s *mapSearch) jumpTo(location string) {
+	m, ok := s.lmap.markerFor(location)
+	if !ok {
+		return
+	}
+	s.suggestions.Hide()
+	s.pin.Hide()
+	addRecentJump(location)
+	s.lmap.view.flyTo(m.lat, m.lon, func() {
+		s.selection.selectLocation(location, true)
+	})
+}
+
+// lieux en texte libre déjà trouvés pendant la session (clé en minuscules) ;
+// gardés à part du cache des lieux de concert (thread UI uniquement)
+var searchedPlaces = make(map[string]*models.LocationCoords)
+
+// lieu quelconque : géocodage en arrière-plan, puis vol et repère
+func (s *mapSearch) geocode(query string) {
+	key := strings.ToLower(query)
+	if coords, ok := searchedPlaces[key]; ok {
+		s.showFound(query, coords)
+		return
+	}
+
+	s.items.RemoveAll()
+	s.items.Add(widget.NewLabel(T().Loading))
+	s.suggestions.Refresh()
+
+	go func() {
+		coords := nominatimSearch(query, query)
+		fyne.Do(func() {
+			if coords != nil && (coords.Latitude != 0 || coords.Longitude != 0) {
+				searchedPlaces[key] = coords
+			}
+			s.showFound(query, coords)
+		})
+	}()
+}
+
+// résultat du géocodeur : message si introuvable ou hors carte, sinon vol et repère
+func (s *mapSearch) showFound(query string, coords *models.LocationCoords) {
+	if coords == nil || (coords.Latitude == 0 && coords.Longitude == 0) {
+		s.items.RemoveAll()
+		s.items.Add(widget.NewLabel(fmt.Sprintf(T().HomeNotFoundFmt, query)))
+		s.suggestions.Refresh()
+		return
+	}
+	if !s.lmap.view.contains(coords.Latitude, coords.Longitude) {
+		s.items.RemoveAll()
+		s.items.Add(widget.NewLabel(fmt.Sprintf(T().OutsideMapFmt, query)))
+		s.suggestions.Refresh()
+		return
+	}
+
+	s.suggestions.Hide()
+	addRecentJump(query)
+	s.lmap.view.place(s.pin, coords.Latitude, coords.Longitude, fyne.NewSize(14, 14))
+	s.pin.Show()
+	s.lmap.view.flyTo(coords.Latitude, coords.Longitude, func() {
+		// on ouvre le lieu de concert le plus proche s'il est tout près
+		if loc := s.nearestLocation(coords.Latitude, coords.Longitude, 50); loc != "" {
+			s.selection.selectLocation(loc, true)
+		}
+	})
+}
+
+// lieu de concert le plus proche à moins de maxKm, "" sinon
+func (s *mapSearch) nearestLocation(lat, lon, maxKm float64) string {
+	type candidate struct {
+		location string
+		km       float64
+	}
+	var near []candidate
+	for _, loc := range s.selection.all {
+		if km := models.HaversineKm(lat, lon, loc.Latitude, loc.Longitude); km <= maxKm {
+			near = append(near, candidate{loc.Lieux, km})
+		}
+	}
+	if len(near) == 0 {
+		return ""
+	}
+	sort.Slice(near, func(i, j int) bool { return near[i].km < near[j].km })
+	return near[0].location
+}
+
+// historique, plus récent d'abord
+func recentJumps() []string {
+	prefs := appPreferences()
+	if prefs == nil {
+		return nil
+	}
+	return prefs.StringList(prefRecentJumps)
+}
+
+func addRecentJump(query string) {
+	prefs := appPreferences()
+	if prefs == nil {
+		return
+	}
+	recent := []string{query}
+	for _, r := range prefs.StringList(prefRecentJumps) {
+		if r != query && len(recent) < maxRecentJumps {
+			recent = append(recent, r)
+		}
+	}
+	prefs.SetStringList(prefRecentJumps, recent)
+}
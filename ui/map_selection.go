@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// sélection liée entre les marqueurs et la liste des lieux, avec une recherche commune
+type locationSelection struct {
+	lmap     *locationMap
+	concerts map[string][]ConcertInfo
+	all      []*models.LocationCoords // triés par nom
+	visible  []*models.LocationCoords // filtrés par la recherche
+	list     *widget.List
+	search   *widget.Entry
+	selected string
+
+	onSelect func(location string) // lieu sélectionné (liste ou carte)
+}
+
+func newLocationSelection(lmap *locationMap, locations []*models.LocationCoords, concerts map[string][]ConcertInfo) *locationSelection {
+	s := &locationSelection{lmap: lmap, concerts: concerts}
+
+	s.all = append([]*models.LocationCoords(nil), locations...)
+	sort.Slice(s.all, func(i, j int) bool {
+		return displayLocationName(s.all[i].Lieux) < displayLocationName(s.all[j].Lieux)
+	})
+	s.visible = s.all
+
+	s.list = widget.NewList(
+		func() int { return len(s.visible) },
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("")
+			name.TextStyle = fyne.TextStyle{Bold: true}
+			name.Truncation = fyne.TextTruncateEllipsis
+			artists := widget.NewLabel("")
+			artists.Truncation = fyne.TextTruncateEllipsis
+			dates := widget.NewLabel("")
+			dates.Truncation = fyne.TextTruncateEllipsis
+			return container.NewVBox(name, artists, dates)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			loc := s.visible[id]
+			box := obj.(*fyne.Container)
+			// le lieu sélectionné est marqué dans la ligne (la liste ne garde pas de sélection)
+			bullet := "•"
+			if loc.Lieux == s.selected {
+				bullet = "▶"
+			}
+			box.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%s %s (%.4f, %.4f)", bullet, displayLocationName(loc.Lieux), loc.Latitude, loc.Longitude))
+			box.Objects[1].(*widget.Label).SetText("♫ " + strings.Join(s.artistNames(loc.Lieux), ", "))
+			box.Objects[2].(*widget.Label).SetText(s.datesLine(loc.Lieux))
+		},
+	)
+	s.list.OnSelected = func(id widget.ListItemID) {
+		if id < len(s.visible) {
+			s.selectLocation(s.visible[id].Lieux, false)
+		}
+		// sans sélection gardée, toucher de nouveau la même ligne recentre la carte
+		s.list.UnselectAll()
+	}
+
+	s.search = widget.NewEntry()
+	s.search.SetPlaceHolder(T().FilterLocations)
+	s.search.OnChanged = s.applyFilter
+
+	return s
+}
+
+// ajoute un lieu géocodé (tri par nom, recherche en cours appliquée)
+func (s *locationSelection) add(loc *models.LocationCoords) {
+	name := displayLocationName(loc.Lieux)
+	i := sort.Search(len(s.all), func(i int) bool { return displayLocationName(s.all[i].Lieux) >= name })
+	s.all = append(s.all, nil)
+	copy(s.all[i+1:], s.all[i:])
+	s.all[i] = loc
+	s.applyFilter(s.search.Text)
+}
+
+// contenu du panneau : titre, recherche et liste
+func (s *locationSelection) content() fyne.CanvasObject {
+	titleLabel := widget.NewLabel(T().LocationsListTitle)
+	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	return container.NewBorder(
+		container.NewVBox(titleLabel, s.search, widget.NewSeparator()),
+		nil, nil, nil,
+		s.list,
+	)
+}
+
+// artistes ayant joué dans ce lieu
+func (s *locationSelection) artistNames(location string) []string {
+	var names []string
+	for _, c := range s.concerts[location] {
+		names = append(names, c.Artist)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// premières dates de concert du lieu, tous artistes confondus
+func (s *locationSelection) datesLine(location string) string {
+	const maxDates = 3
+	var dates []string
+	for _, c := range s.concerts[location] {
+		dates = append(dates, c.Dates...)
+	}
+	dates = models.SortedConcertDates(dates)
+	if len(dates) == 0 {
+		return ""
+	}
+	line := "📅 " + strings.Join(dates[:min(len(dates), maxDates)], ", ")
+	if len(dates) > maxDates {
+		line += " " + fmt.Sprintf(T().MoreDatesFmt, len(dates)-maxDates)
+	}
+	return strings.ReplaceAll(line, "*", "")
+}
+
+// sélectionne un lieu ; fromMap : clic sur le marqueur (sinon clic dans la liste)
+func (s *locationSelection) selectLocation(location string, fromMap bool) {
+	if s.lmap != nil {
+		if s.selected != "" {
+			s.lmap.highlight(s.selected, false)
+		}
+		s.lmap.highlight(location, true)
+	}
+	s.selected = location
+	s.list.Refresh()
+	if s.onSelect != nil {
+		s.onSelect(location)
+	}
+
+	if fromMap {
+		// on amène l'entrée de la liste à l'écran
+		for i, loc := range s.visible {
+			if loc.Lieux == location {
+				s.list.ScrollTo(i)
+				return
+			}
+		}
+		return
+	}
+
+	// clic dans la liste : on centre la carte sur le marqueur
+	if m, ok := s.lmap.markerFor(location); ok {
+		s.lmap.view.centerOn(m.lat, m.lon)
+	}
+}
+
+// filtre liste + marqueurs (nom du lieu ou artiste)
+func (s *locationSelection) applyFilter(text string) {
+	query := strings.ToLower(strings.TrimSpace(text))
+
+	s.visible = s.visible[:0:0]
+	for _, loc := range s.all {
+		match := query == "" || strings.Contains(strings.ToLower(displayLocationName(loc.Lieux)), query)
+		if !match {
+			for _, name := range s.artistNames(loc.Lieux) {
+				if strings.Contains(strings.ToLower(name), query) {
+					match = true
+					break
+				}
+			}
+		}
+		if match {
+			s.visible = append(s.visible, loc)
+		}
+		if s.lmap != nil {
+			s.lmap.setVisible(loc.Lieux, match)
+		}
+	}
+
+	s.list.Refresh()
+}
+
+// "new_york-usa" -> "new york, usa"
+func displayLocationName(location string) string {
+	name := strings.ReplaceAll(location, "_", " ")
+	return strings.ReplaceAll(name, "-", ", ")
+}
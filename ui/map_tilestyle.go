@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"golang.org/x/image/draw"
+)
+
+// préférence : style des tuiles OSM
+const prefTileStyle = "map.tile_style"
+
+// styles de tuiles
+const (
+	tileStyleOriginal = "original"
+	tileStyleDark     = "dark"
+)
+
+// traitement appliqué aux tuiles décodées, dans l'ordre : inversion,
+// désaturation (0 = couleurs d'origine, 1 = niveaux de gris) puis teinte
+// (chaque canal est ramené entre shadow et highlight)
+type tileFilter struct {
+	invert     bool
+	desaturate float64
+	shadow     color.RGBA
+	highlight  color.RGBA
+}
+
+// style sombre : fond BgDarker, routes et textes en cyan pâle
+var darkTileFilter = tileFilter{
+	invert:     true,
+	desaturate: 0.9,
+	shadow:     BgDarker,
+	highlight:  color.RGBA{R: 150, G: 205, B: 230, A: 255},
+}
+
+// version du traitement dans le nom des fichiers en cache (à changer avec darkTileFilter)
+const darkTileVersion = "dark1"
+
+// style courant (préférences), original par défaut
+func currentTileStyle() string {
+	if prefs := appPreferences(); prefs != nil && prefs.String(prefTileStyle) == tileStyleDark {
+		return tileStyleDark
+	}
+	return tileStyleOriginal
+}
+
+func setTileStyle(style string) {
+	if prefs := appPreferences(); prefs != nil {
+		prefs.SetString(prefTileStyle, style)
+	}
+}
+
+// applique le filtre pixel par pixel
+func (f tileFilter) apply(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+
+	remap := func(v, lo, hi uint8) uint8 {
+		return uint8(float64(lo) + (float64(hi)-float64(lo))*float64(v)/255)
+	}
+	pix := dst.Pix
+	for i := 0; i+3 < len(pix); i += 4 {
+		r, g, bl := float64(pix[i]), float64(pix[i+1]), float64(pix[i+2])
+		if f.invert {
+			r, g, bl = 255-r, 255-g, 255-bl
+		}
+		if f.desaturate > 0 {
+			l := 0.299*r + 0.587*g + 0.114*bl
+			r += (l - r) * f.desaturate
+			g += (l - g) * f.desaturate
+			bl += (l - bl) * f.desaturate
+		}
+		pix[i] = remap(uint8(r), f.shadow.R, f.highlight.R)
+		pix[i+1] = remap(uint8(g), f.shadow.G, f.highlight.G)
+		pix[i+2] = remap(uint8(bl), f.shadow.B, f.highlight.B)
+		pix[i+3] = 255
+	}
+	return dst
+}
+
+// applique le style à une tuile décodée (original : inchangée)
+func styleTileImage(img image.Image, style string) image.Image {
+	if style != tileStyleDark {
+		return img
+	}
+	return darkTileFilter.apply(img)
+}
+
+// tuile brute : cache disque, sinon serveur OSM (nil si indisponible)
+func rawTileBytes(client *http.Client, zoom, x, y int) []byte {
+	path := tileCachePath(zoom, x, y)
+	b, err := os.ReadFile(path)
+	if err == nil && looksLikePNG(b) {
+		return b
+	}
+	_ = os.Remove(path)
+	b = getTileBytes(client, zoom, x, y)
+	if len(b) == 0 {
+		return nil
+	}
+	_ = os.WriteFile(path, b, 0o644)
+	return b
+}
+
+// chemin en cache d'une tuile traitée ("dark1_5_16_10.png", "dark1@2x_...")
+func styledTileCachePath(variant string, zoom, x, y int) string {
+	dir := filepath.Dir(tileCachePath(zoom, x, y))
+	return filepath.Join(dir, fmt.Sprintf("%s_%d_%d_%d.png", variant, zoom, x, y))
+}
+
+// PNG d'une tuile à afficher dans le style demandé ; hidpi : tuile agrandie
+// à 512 px (lissée, plus nette sur écran x2 que l'étirement du canvas) sans
+// requête de plus au serveur de tuiles
+func displayTileBytes(client *http.Client, zoom, x, y int, style string, hidpi bool) []byte {
+	if style == tileStyleOriginal && !hidpi {
+		return rawTileBytes(client, zoom, x, y)
+	}
+
+	variant := style
+	if style == tileStyleDark {
+		variant = darkTileVersion
+	}
+	if hidpi {
+		variant += "@2x"
+	}
+	path := styledTileCachePath(variant, zoom, x, y)
+	if b, err := os.ReadFile(path); err == nil && looksLikePNG(b) {
+		return b
+	}
+
+	b := rawTileBytes(client, zoom, x, y)
+	if b == nil {
+		return nil
+	}
+	img := decodeTile(b, zoom, x, y)
+	if img == nil {
+		return nil
+	}
+	img = styleTileImage(img, style)
+	if hidpi {
+		img = upscaleTile(img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		log.Printf("[TILE STYLE] zoom=%d x=%d y=%d: %v\n", zoom, x, y, err)
+		return nil
+	}
+	_ = os.WriteFile(path, buf.Bytes(), 0o644)
+	return buf.Bytes()
+}
+
+// tuile agrandie en 512x512 (Catmull-Rom)
+func upscaleTile(src image.Image) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, 2*tileSize, 2*tileSize))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	return dst
+}
+
+func decodeTile(b []byte, zoom, x, y int) image.Image {
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		log.Printf("[TILE DECODE] zoom=%d x=%d y=%d: %v\n", zoom, x, y, err)
+		return nil
+	}
+	return img
+}
+
+// écran HiDPI (échelle >= 1.5) : on affiche des tuiles de 512 px (thread UI)
+func tileHiDPI() bool {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return false
+	}
+	for _, w := range app.Driver().AllWindows() {
+		if w.Canvas() != nil && w.Canvas().Scale() >= 1.5 {
+			return true
+		}
+	}
+	return false
+}
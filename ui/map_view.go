@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"image/color"
+	"log"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// tuiles OSM 256x256
+const tileSize = 256
+
+// vue carte réutilisable (page carte, mini carte artiste) : tuiles + calques dans un scroll
+type mapView struct {
+	zoom int
+	xMin int
+	xMax int
+	yMin int
+	yMax int
+	size fyne.Size // taille totale de la grille de tuiles
+
+	base    *fyne.Container // fond vectoriel hors ligne (sous les tuiles)
+	tiles   *fyne.Container // placeholders + tuiles téléchargées
+	layers  *fyne.Container // calques thématiques (choroplèthe...) sous les marqueurs
+	markers *fyne.Container // marqueurs
+	overlay *fyne.Container // infobulles (au-dessus de tout)
+	content *fyne.Container // pile des calques (sans layout)
+	scroll  *container.Scroll
+
+	fly *fyne.Animation // vol en cours vers un lieu
+
+	tilesStarted bool // téléchargement des tuiles lancé
+	tileGen      int  // incrémenté à chaque rechargement (ignore les tuiles périmées)
+}
+
+// newMapView choisit le zoom (<= maxZoom) pour que les lieux tiennent dans viewSize,
+// avec au plus maxTiles tuiles ; onTap reçoit la position géographique d'un clic
+func newMapView(locations []*models.LocationCoords, viewSize fyne.Size, maxZoom, maxTiles int, onTap func(lat, lon float64)) *mapView {
+	log.Println("Determining bounding box...")
+	// determine bounding box
+	minLat := locations[0].Latitude
+	maxLat := locations[0].Latitude
+	minLon := locations[0].Longitude
+	maxLon := locations[0].Longitude
+	for _, loc := range locations {
+		minLat = math.Min(minLat, loc.Latitude)
+		maxLat = math.Max(maxLat, loc.Latitude)
+		minLon = math.Min(minLon, loc.Longitude)
+		maxLon = math.Max(maxLon, loc.Longitude)
+	}
+
+	// padding
+	latRange := math.Max(maxLat-minLat, 0.1)
+	lonRange := math.Max(maxLon-minLon, 0.1)
+	minLat -= latRange * 0.1
+	maxLat += latRange * 0.1
+	minLon -= lonRange * 0.1
+	maxLon += lonRange * 0.1
+	minLat, maxLat = math.Max(minLat, -85), math.Min(maxLat, 85)
+
+	// zoom le plus fort où la zone tient dans la vue
+	zoom := 1
+	for z := maxZoom; z >= 1; z-- {
+		tx1, ty1 := latLonToTileXY(maxLat, minLon, z)
+		tx2, ty2 := latLonToTileXY(minLat, maxLon, z)
+		if math.Abs(tx2-tx1)*tileSize <= float64(viewSize.Width) && math.Abs(ty2-ty1)*tileSize <= float64(viewSize.Height) {
+			zoom = z
+			break
+		}
+	}
+
+	// plage de tuiles (au moins la taille de la vue), bornée au monde
+	tileRange := func(z int) (int, int, int, int) {
+		tx1, ty1 := latLonToTileXY(maxLat, minLon, z)
+		tx2, ty2 := latLonToTileXY(minLat, maxLon, z)
+		xMin, xMax := int(math.Floor(tx1)), int(math.Floor(tx2))
+		yMin, yMax := int(math.Floor(ty1)), int(math.Floor(ty2))
+
+		maxTileCoord := 1<<z - 1
+		minX := int(math.Ceil(float64(viewSize.Width) / tileSize))
+		minY := int(math.Ceil(float64(viewSize.Height) / tileSize))
+		for xMax-xMin+1 < minX && (xMin > 0 || xMax < maxTileCoord) {
+			if xMin > 0 {
+				xMin--
+			}
+			if xMax-xMin+1 < minX && xMax < maxTileCoord {
+				xMax++
+			}
+		}
+		for yMax-yMin+1 < minY && (yMin > 0 || yMax < maxTileCoord) {
+			if yMin > 0 {
+				yMin--
+			}
+			if yMax-yMin+1 < minY && yMax < maxTileCoord {
+				yMax++
+			}
+		}
+
+		xMin, yMin = max(xMin, 0), max(yMin, 0)
+		xMax, yMax = min(xMax, maxTileCoord), min(yMax, maxTileCoord)
+		return xMin, xMax, yMin, yMax
+	}
+
+	xMin, xMax, yMin, yMax := tileRange(zoom)
+	for z := zoom - 1; z >= 1 && (xMax-xMin+1)*(yMax-yMin+1) > maxTiles; z-- {
+		xMin, xMax, yMin, yMax = tileRange(z)
+		log.Printf("Tile grid too large at zoom %d, clamped to zoom %d\n", zoom, z)
+		zoom = z
+	}
+
+	m := &mapView{
+		zoom: zoom,
+		xMin: xMin, xMax: xMax,
+		yMin: yMin, yMax: yMax,
+		size: fyne.NewSize(float32((xMax-xMin+1)*tileSize), float32((yMax-yMin+1)*tileSize)),
+	}
+	log.Printf("Tile grid: %dx%d at zoom %d\n", xMax-xMin+1, yMax-yMin+1, zoom)
+
+	// fond uni tout de suite, puis le fond vectoriel (hors ligne) dès qu'il est rendu ;
+	// les tuiles OSM se posent par-dessus au fil du téléchargement
+	ocean := canvas.NewRectangle(basemapOcean)
+	ocean.Resize(m.size)
+	m.base = container.NewWithoutLayout(ocean)
+	m.base.Resize(m.size)
+	m.tiles = container.NewWithoutLayout()
+	m.tiles.Resize(m.size)
+	go m.loadBasemap()
+
+	// calque de clic sous les marqueurs : pixel -> lat/lon
+	tap := newTapLayer(func(pos fyne.Position) {
+		if onTap != nil {
+			onTap(m.unproject(pos))
+		}
+	})
+	tap.Resize(m.size)
+
+	m.layers = container.NewWithoutLayout()
+	m.layers.Resize(m.size)
+	m.markers = container.NewWithoutLayout()
+	m.markers.Resize(m.size)
+	m.overlay = container.NewWithoutLayout()
+	m.overlay.Resize(m.size)
+
+	m.content = container.NewWithoutLayout(m.base, m.tiles, m.layers, tap, m.markers, m.overlay)
+	m.content.Resize(m.size)
+	m.scroll = container.NewScroll(m.content)
+
+	m.setVectorOnly(useVectorBasemap())
+	return m
+}
+
+// rend le fond vectoriel en arrière-plan puis l'ajoute sous les tuiles
+func (m *mapView) loadBasemap() {
+	img := canvas.NewImageFromImage(renderViewBasemap(m))
+	img.FillMode = canvas.ImageFillStretch
+	img.Resize(m.size)
+	fyne.Do(func() {
+		m.base.Add(img)
+		m.base.Refresh()
+	})
+}
+
+// fond vectoriel seul (tuiles masquées, pas de téléchargement) ou tuiles OSM par-dessus
+func (m *mapView) setVectorOnly(on bool) {
+	if on {
+		m.tiles.Hide()
+		return
+	}
+	m.tiles.Show()
+	if !m.tilesStarted {
+		m.tilesStarted = true
+		go m.loadTiles(m.tileGen)
+	}
+}
+
+// lat/lon -> position dans la grille
+func (m *mapView) project(lat, lon float64) fyne.Position {
+	tx, ty := latLonToTileXY(lat, lon, m.zoom)
+	return fyne.NewPos(float32((tx-float64(m.xMin))*tileSize), float32((ty-float64(m.yMin))*tileSize))
+}
+
+// position dans la grille -> lat/lon
+func (m *mapView) unproject(pos fyne.Position) (float64, float64) {
+	return tileXYToLatLon(float64(m.xMin)+float64(pos.X)/tileSize, float64(m.yMin)+float64(pos.Y)/tileSize, m.zoom)
+}
+
+// place un objet centré sur un point géographique
+func (m *mapView) place(obj fyne.CanvasObject, lat, lon float64, size fyne.Size) {
+	p := m.project(lat, lon)
+	obj.Resize(size)
+	obj.Move(fyne.NewPos(p.X-size.Width/2, p.Y-size.Height/2))
+}
+
+// fait défiler la vue pour centrer le point
+func (m *mapView) centerOn(lat, lon float64) {
+	p := m.project(lat, lon)
+	view := m.scroll.Size()
+	m.scroll.ScrollToOffset(fyne.NewPos(p.X-view.Width/2, p.Y-view.Height/2))
+}
+
+// recharge les tuiles (changement de style)
+func (m *mapView) reloadTiles() {
+	m.tileGen++
+	m.tiles.RemoveAll()
+	if m.tilesStarted {
+		go m.loadTiles(m.tileGen)
+	}
+}
+
+// durée d'un "vol" vers un lieu
+const flyDuration = 600 * time.Millisecond
+
+// anime le défilement jusqu'à centrer le point, puis appelle done (peut être nil)
+func (m *mapView) flyTo(lat, lon float64, done func()) {
+	if m.fly != nil {
+		m.fly.Stop()
+	}
+	p := m.project(lat, lon)
+	view := m.scroll.Size()
+	from := m.scroll.Offset
+	to := fyne.NewPos(
+		max(0, min(p.X-view.Width/2, m.size.Width-view.Width)),
+		max(0, min(p.Y-view.Height/2, m.size.Height-view.Height)),
+	)
+
+	m.fly = fyne.NewAnimation(flyDuration, func(t float32) {
+		m.scroll.ScrollToOffset(fyne.NewPos(from.X+(to.X-from.X)*t, from.Y+(to.Y-from.Y)*t))
+		if t == 1 && done != nil {
+			done()
+			done = nil
+		}
+	})
+	m.fly.Curve = fyne.AnimationEaseInOut
+	m.fly.Start()
+}
+
+// le point est-il dans la grille de tuiles de la vue ?
+func (m *mapView) contains(lat, lon float64) bool {
+	p := m.project(lat, lon)
+	return p.X >= 0 && p.Y >= 0 && p.X <= m.size.Width && p.Y <= m.size.Height
+}
+
+// télécharge les tuiles en arrière-plan (style choisi, 512 px sur écran HiDPI) ;
+// gen : génération de tuiles au lancement
+func (m *mapView) loadTiles(gen int) {
+	var wg sync.WaitGroup
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	maxConcurrent := 4
+	semaphore := make(chan struct{}, maxConcurrent)
+
+	total := (m.xMax - m.xMin + 1) * (m.yMax - m.yMin + 1)
+	var mu sync.Mutex
+	loaded := 0
+
+	// échelle des fenêtres lue sur le thread UI
+	style := currentTileStyle()
+	var hidpi bool
+	fyne.DoAndWait(func() {
+		hidpi = tileHiDPI()
+	})
+
+	for x := m.xMin; x <= m.xMax; x++ {
+		for y := m.yMin; y <= m.yMax; y++ {
+			wg.Add(1)
+			go func(tx, ty int) {
+				defer wg.Done()
+				semaphore <- struct{}{}        // acquire
+				defer func() { <-semaphore }() // release
+
+				time.Sleep(30 * time.Millisecond)
+
+				b := displayTileBytes(client, m.zoom, tx, ty, style, hidpi)
+				if len(b) == 0 {
+					return
+				}
+
+				mu.Lock()
+				loaded++
+				if loaded%10 == 0 || loaded == total {
+					log.Printf("Loading tiles: %d/%d\n", loaded, total)
+				}
+				mu.Unlock()
+
+				// Update UI immediately
+				fyne.Do(func() {
+					if gen != m.tileGen {
+						return
+					}
+					res := fyne.NewStaticResource(fmt.Sprintf("%s_%d_%d_%d.png", style, m.zoom, tx, ty), b)
+					img := canvas.NewImageFromResource(res)
+					img.FillMode = canvas.ImageFillStretch
+					img.Move(fyne.NewPos(float32((tx-m.xMin)*tileSize), float32((ty-m.yMin)*tileSize)))
+					img.Resize(fyne.NewSize(tileSize, tileSize))
+					m.tiles.Add(img)
+					m.tiles.Refresh()
+				})
+			}(x, y)
+		}
+	}
+	wg.Wait()
+	log.Printf("Finished loading %d tiles (expected %d)\n", loaded, total)
+}
+
+// inverse : coords de tuile (flottantes) -> lat/lon
+func tileXYToLatLon(x, y float64, zoom int) (float64, float64) {
+	n := math.Pow(2, float64(zoom))
+	lon := x/n*360.0 - 180.0
+	lat := math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180.0 / math.Pi
+	return lat, lon
+}
+
+// calque transparent cliquable (clics sur la carte, cartes de lieux...)
+type tapLayer struct {
+	widget.BaseWidget
+	onTapped func(fyne.Position)
+}
+
+func newTapLayer(onTapped func(fyne.Position)) *tapLayer {
+	t := &tapLayer{onTapped: onTapped}
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+func (t *tapLayer) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+func (t *tapLayer) Tapped(e *fyne.PointEvent) {
+	if t.onTapped != nil {
+		t.onTapped(e.Position)
+	}
+}
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// dimensions du curseur double
+const (
+	sliderHistHeight  = 28 // hauteur max des barres
+	sliderThumbRadius = 8
+	sliderTrackHeight = 4
+	sliderTextSize    = 11
+)
+
+// barres de l'histogramme dans la sélection (les autres sont en CardBgActive)
+var sliderBarIn = color.NRGBA{R: AccentCyan.R, G: AccentCyan.G, B: AccentCyan.B, A: 140}
+
+// curseur à deux poignées sur des valeurs entières (années), avec
+// l'histogramme des artistes par valeur au-dessus de la piste.
+// Clavier : ←/→ déplacent la poignée active, PageUp/PageDown de 10,
+// Début/Fin vont aux bornes, Espace change de poignée.
+type rangeSlider struct {
+	widget.BaseWidget
+
+	min, max  int
+	low, high int
+	counts    map[int]int // artistes par valeur
+
+	OnChanged func(low, high int)
+
+	active   int // poignée pilotée au clavier : 0 basse, 1 haute
+	dragging int // poignée tirée à la souris, -1 : aucune
+	focused  bool
+}
+
+func newRangeSlider(min, max int, onChanged func(low, high int)) *rangeSlider {
+	s := &rangeSlider{min: min, max: max, low: min, high: max, OnChanged: onChanged, dragging: -1}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// sélection venue d'ailleurs (requête, réinitialisation) : OnChanged n'est pas appelé
+func (s *rangeSlider) setRange(low, high int) {
+	s.low, s.high = s.clamp(low), s.clamp(high)
+	s.Refresh()
+}
+
+// histogramme à afficher
+func (s *rangeSlider) setCounts(counts map[int]int) {
+	s.counts = counts
+	s.Refresh()
+}
+
+func (s *rangeSlider) clamp(v int) int {
+	return max(s.min, min(v, s.max))
+}
+
+// déplace une poignée sans croiser l'autre, puis prévient
+func (s *rangeSlider) moveThumb(thumb, v int) {
+	v = s.clamp(v)
+	if thumb == 0 {
+		v = min(v, s.high)
+		if v == s.low {
+			return
+		}
+		s.low = v
+	} else {
+		v = max(v, s.low)
+		if v == s.high {
+			return
+		}
+		s.high = v
+	}
+	s.Refresh()
+	if s.OnChanged != nil {
+		s.OnChanged(s.low, s.high)
+	}
+}
+
+// marge de chaque côté pour que les poignées tiennent dans le widget
+func (s *rangeSlider) inset() float32 { return sliderThumbRadius + 2 }
+
+func (s *rangeSlider) valueToX(v int) float32 {
+	w := s.Size().Width - 2*s.inset()
+	if s.max == s.min {
+		return s.inset() + w/2
+	}
+	return s.inset() + w*float32(v-s.min)/float32(s.max-s.min)
+}
+
+func (s *rangeSlider) xToValue(x float32) int {
+	w := s.Size().Width - 2*s.inset()
+	if w <= 0 || s.max == s.min {
+		return s.min
+	}
+	t := float64((x - s.inset()) / w)
+	return s.clamp(s.min + int(math.Round(t*float64(s.max-s.min))))
+}
+
+// poignée la plus proche d'une abscisse (la haute quand elles se touchent et qu'on est à droite)
+func (s *rangeSlider) nearestThumb(x float32) int {
+	dLow := math.Abs(float64(x - s.valueToX(s.low)))
+	dHigh := math.Abs(float64(x - s.valueToX(s.high)))
+	if dHigh < dLow || (dHigh == dLow && x > s.valueToX(s.high)) {
+		return 1
+	}
+	return 0
+}
+
+func (s *rangeSlider) Tapped(e *fyne.PointEvent) {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(s); c != nil {
+		c.Focus(s)
+	}
+	s.active = s.nearestThumb(e.Position.X)
+	s.moveThumb(s.active, s.xToValue(e.Position.X))
+	s.Refresh()
+}
+
+func (s *rangeSlider) Dragged(e *fyne.DragEvent) {
+	if s.dragging < 0 {
+		s.dragging = s.nearestThumb(e.Position.X - e.Dragged.DX)
+		s.active = s.dragging
+	}
+	s.moveThumb(s.dragging, s.xToValue(e.Position.X))
+}
+
+func (s *rangeSlider) DragEnd() {
+	s.dragging = -1
+}
+
+func (s *rangeSlider) FocusGained() {
+	s.focused = true
+	s.Refresh()
+}
+
+func (s *rangeSlider) FocusLost() {
+	s.focused = false
+	s.Refresh()
+}
+
+func (s *rangeSlider) TypedRune(r rune) {
+	if r == ' ' {
+		s.active = 1 - s.active
+		s.Refresh()
+	}
+}
+
+func (s *rangeSlider) TypedKey(e *fyne.KeyEvent) {
+	current := s.low
+	if s.active == 1 {
+		current = s.high
+	}
+	switch e.Name {
+	case fyne.KeyLeft, fyne.KeyDown:
+		s.moveThumb(s.active, current-1)
+	case fyne.KeyRight, fyne.KeyUp:
+		s.moveThumb(s.active, current+1)
+	case fyne.KeyPageDown:
+		s.moveThumb(s.active, current-10)
+	case fyne.KeyPageUp:
+		s.moveThumb(s.active, current+10)
+	case fyne.KeyHome:
+		s.moveThumb(s.active, s.min)
+	case fyne.KeyEnd:
+		s.moveThumb(s.active, s.max)
+	}
+}
+
+func (s *rangeSlider) CreateRenderer() fyne.WidgetRenderer {
+	r := &rangeSliderRenderer{
+		slider: s,
+		track:  canvas.NewRectangle(CardBgLight),
+		fill:   canvas.NewRectangle(AccentCyan),
+	}
+	for i := range r.thumbs {
+		r.thumbs[i] = canvas.NewCircle(TextWhite)
+		r.texts[i] = canvas.NewText("", TextLight)
+		r.texts[i].TextSize = sliderTextSize
+	}
+	r.track.CornerRadius = sliderTrackHeight / 2
+	r.fill.CornerRadius = sliderTrackHeight / 2
+	r.rebuildBars()
+	return r
+}
+
+var (
+	_ fyne.Tappable  = (*rangeSlider)(nil)
+	_ fyne.Draggable = (*rangeSlider)(nil)
+	_ fyne.Focusable = (*rangeSlider)(nil)
+)
+
+type rangeSliderRenderer struct {
+	slider *rangeSlider
+	bars   []*canvas.Rectangle // une barre par valeur, de min à max
+	track  *canvas.Rectangle
+	fill   *canvas.Rectangle
+	thumbs [2]*canvas.Circle
+	texts  [2]*canvas.Text
+}
+
+func (r *rangeSliderRenderer) rebuildBars() {
+	n := max(0, r.slider.max-r.slider.min+1)
+	if len(r.bars) == n {
+		return
+	}
+	r.bars = make([]*canvas.Rectangle, n)
+	for i := range r.bars {
+		r.bars[i] = canvas.NewRectangle(CardBgLight)
+	}
+}
+
+func (r *rangeSliderRenderer) Layout(size fyne.Size) {
+	s := r.slider
+	trackY := float32(sliderHistHeight + sliderThumbRadius)
+
+	// histogramme : hauteur proportionnelle au nombre d'artistes
+	maxCount := 0
+	for _, c := range s.counts {
+		maxCount = max(maxCount, c)
+	}
+	barW := float32(1)
+	if len(r.bars) > 1 {
+		barW = max(1, (s.valueToX(s.max)-s.valueToX(s.min))/float32(len(r.bars))-1)
+	}
+	for i, bar := range r.bars {
+		v := s.min + i
+		h := float32(0)
+		if maxCount > 0 {
+			h = sliderHistHeight * float32(s.counts[v]) / float32(maxCount)
+		}
+		bar.Resize(fyne.NewSize(barW, h))
+		bar.Move(fyne.NewPos(s.valueToX(v)-barW/2, sliderHistHeight-h))
+	}
+
+	r.track.Resize(fyne.NewSize(size.Width-2*s.inset(), sliderTrackHeight))
+	r.track.Move(fyne.NewPos(s.inset(), trackY-sliderTrackHeight/2))
+	lowX, highX := s.valueToX(s.low), s.valueToX(s.high)
+	r.fill.Resize(fyne.NewSize(highX-lowX, sliderTrackHeight))
+	r.fill.Move(fyne.NewPos(lowX, trackY-sliderTrackHeight/2))
+
+	for i, x := range []float32{lowX, highX} {
+		r.thumbs[i].Resize(fyne.NewSquareSize(2 * sliderThumbRadius))
+		r.thumbs[i].Move(fyne.NewPos(x-sliderThumbRadius, trackY-sliderThumbRadius))
+
+		// valeur sous la poignée, gardée dans le widget
+		text := r.texts[i]
+		ts := text.MinSize()
+		tx := max(0, min(x-ts.Width/2, size.Width-ts.Width))
+		if i == 1 && s.low != s.high {
+			// évite que les deux valeurs se chevauchent
+			lowText := r.texts[0]
+			tx = max(tx, lowText.Position().X+lowText.MinSize().Width+4)
+		}
+		text.Resize(ts)
+		text.Move(fyne.NewPos(tx, trackY+sliderThumbRadius+2))
+	}
+}
+
+func (r *rangeSliderRenderer) MinSize() fyne.Size {
+	textH := fyne.MeasureText("0", sliderTextSize, fyne.TextStyle{}).Height
+	return fyne.NewSize(160, sliderHistHeight+2*sliderThumbRadius+2+textH)
+}
+
+func (r *rangeSliderRenderer) Refresh() {
+	s := r.slider
+	r.rebuildBars()
+	for i, bar := range r.bars {
+		v := s.min + i
+		if v >= s.low && v <= s.high {
+			bar.FillColor = sliderBarIn
+		} else {
+			bar.FillColor = CardBgActive
+		}
+	}
+
+	r.texts[0].Text = strconv.Itoa(s.low)
+	r.texts[1].Text = strconv.Itoa(s.high)
+	r.texts[1].Hidden = s.low == s.high
+	for i, thumb := range r.thumbs {
+		thumb.StrokeWidth = 0
+		if s.focused && i == s.active {
+			// poignée pilotée au clavier
+			thumb.StrokeColor = AccentPink
+			thumb.StrokeWidth = 3
+		}
+	}
+	r.Layout(s.Size())
+	canvas.Refresh(s)
+}
+
+func (r *rangeSliderRenderer) Objects() []fyne.CanvasObject {
+	objs := make([]fyne.CanvasObject, 0, len(r.bars)+6)
+	for _, b := range r.bars {
+		objs = append(objs, b)
+	}
+	objs = append(objs, r.track, r.fill, r.thumbs[0], r.thumbs[1], r.texts[0], r.texts[1])
+	return objs
+}
+
+func (r *rangeSliderRenderer) Destroy() {}